@@ -12,19 +12,30 @@ import (
 
 	problemApp "leetcode-api/internal/application/problem"
 	submissionApp "leetcode-api/internal/application/submission"
-	"leetcode-api/internal/infrastructure/executor"
+	"leetcode-api/internal/config"
 	"leetcode-api/internal/infrastructure/persistence/sqlite"
+	"leetcode-api/internal/infrastructure/sandbox"
 	httpInterface "leetcode-api/internal/interfaces/http"
 
 	"gorm.io/gorm"
 )
 
 func main() {
-	// Remove old database for fresh schema
-	os.Remove("leetcode.db")
+	// Become the sandbox helper when re-executed to run a submission
+	sandbox.Init()
+
+	// The database keeps queued submissions across restarts and migrates to
+	// the current schema on open. DB_RESET starts from a fresh one during
+	// development; standalone judges must then be restarted after the API.
+	dbPath := config.String("DB_PATH", "leetcode.db")
+	if config.Bool("DB_RESET") {
+		if err := os.Remove(dbPath); err != nil && !os.IsNotExist(err) {
+			log.Fatal("Failed to reset database:", err)
+		}
+	}
 
 	// Initialize database
-	db, err := sqlite.NewDB("leetcode.db")
+	db, err := sqlite.NewDB(dbPath)
 	if err != nil {
 		log.Fatal("Failed to connect database:", err)
 	}
@@ -37,12 +48,36 @@ func main() {
 	submissionRepo := sqlite.NewSubmissionRepository(db)
 
 	// Initialize executor
-	codeExecutor := executor.New(5 * time.Second)
+	codeExecutor, err := config.CodeExecutor()
+	if err != nil {
+		log.Fatal("Failed to initialize executor:", err)
+	}
 
 	// Initialize services
 	problemService := problemApp.NewService(problemRepo)
 	submissionService := submissionApp.NewService(submissionRepo, problemRepo, codeExecutor)
 
+	// Start the in-process judge unless submissions are judged by cmd/judge
+	judgeCtx, stopJudge := context.WithCancel(context.Background())
+	judgeDone := make(chan struct{})
+	if config.Bool("JUDGE_DISABLED") {
+		log.Println("⚖️  Judging disabled, run cmd/judge against the same database to judge submissions")
+		close(judgeDone)
+	} else {
+		progress := submissionApp.NewProgress()
+		judge := submissionApp.NewJudge(submissionRepo, problemRepo, submissionRepo, codeExecutor, progress, submissionApp.JudgeConfig{
+			Workers:     config.Int("JUDGE_QUEUE_WORKERS", 0),
+			Lease:       time.Duration(config.Int("JUDGE_LEASE_SECONDS", 0)) * time.Second,
+			MaxAttempts: config.Int("JUDGE_MAX_ATTEMPTS", 0),
+		})
+		submissionService.OnQueued(judge.Notify)
+		submissionService.SetProgress(progress)
+		go func() {
+			judge.Run(judgeCtx)
+			close(judgeDone)
+		}()
+	}
+
 	// Initialize router
 	router := httpInterface.NewRouter(problemService, submissionService)
 	engine := router.Setup()
@@ -60,8 +95,11 @@ func main() {
 		log.Println("   GET  /api/problems?page=1&limit=50&category=algorithms&topic=array&search=sum")
 		log.Println("   GET  /api/problems/:slug")
 		log.Println("   GET  /api/topics")
+		log.Println("   GET  /api/languages")
 		log.Println("   POST /api/run")
 		log.Println("   POST /api/submit")
+		log.Println("   GET  /api/submissions/:id")
+		log.Println("   GET  /api/submissions/:id/events")
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatal("Failed to start server:", err)
 		}
@@ -80,6 +118,13 @@ func main() {
 		log.Fatal("Server forced to shutdown:", err)
 	}
 
+	// Submissions still being judged are cancelled and recorded as such
+	stopJudge()
+	select {
+	case <-judgeDone:
+	case <-ctx.Done():
+	}
+
 	log.Println("Server exited")
 }
 
@@ -99,6 +144,13 @@ func seedData(db *gorm.DB) {
 		existing, _ := problemRepo.FindBySlug(context.Background(), p.Slug)
 		if existing == nil {
 			problemRepo.Create(context.Background(), &p)
+			continue
+		}
+		// Databases kept from older versions lack newer seed metadata,
+		// such as signatures and test cases
+		p.ID = existing.ID
+		if err := problemRepo.Update(context.Background(), &p); err != nil {
+			log.Printf("⚠️  Failed to update problem %s: %v", p.Slug, err)
 		}
 	}
 	log.Printf("✅ Seeded %d problems", len(problems))
@@ -0,0 +1,90 @@
+// Package main is the entry point for a judge server.
+//
+// A judge server runs submitted code for the API and standalone judges on
+// other hosts, which reach it by listing its URL in JUDGE_EXECUTOR_URLS.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"leetcode-api/internal/config"
+	"leetcode-api/internal/infrastructure/executor"
+	"leetcode-api/internal/infrastructure/sandbox"
+)
+
+func main() {
+	// Become the sandbox helper when re-executed to run a submission
+	sandbox.Init()
+
+	// Initialize executor
+	codeExecutor, err := config.LocalExecutor()
+	if err != nil {
+		log.Fatal("Failed to initialize executor:", err)
+	}
+
+	token := os.Getenv("JUDGE_TOKEN")
+	addr, err := listenAddr(token)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Create server
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           executor.NewServer(codeExecutor, token),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Start server in goroutine
+	go func() {
+		log.Printf("⚖️  Judge server running on %s", srv.Addr)
+		log.Println("   GET  /health")
+		log.Println("   POST /execute")
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal("Failed to start server:", err)
+		}
+	}()
+
+	// Graceful shutdown, letting submissions being judged finish
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Println("Shutting down server...")
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Fatal("Server forced to shutdown:", err)
+	}
+
+	log.Println("Server exited")
+}
+
+// listenAddr returns the address to serve on, EXECUTOR_ADDR or port 9090.
+// Without a token anyone who can reach the server can run code on it, so
+// it then defaults to loopback and refuses to listen anywhere else.
+func listenAddr(token string) (string, error) {
+	if token != "" {
+		return config.String("EXECUTOR_ADDR", ":9090"), nil
+	}
+
+	addr := config.String("EXECUTOR_ADDR", "127.0.0.1:9090")
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid EXECUTOR_ADDR %q: %w", addr, err)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return "", fmt.Errorf("set JUDGE_TOKEN to serve on %s; without one the server only listens on loopback", addr)
+	}
+	log.Println("⚠️  JUDGE_TOKEN not set, only local clients can reach the server")
+	return addr, nil
+}
@@ -0,0 +1,62 @@
+// Package main is the entry point for a standalone judge.
+//
+// A judge works through the submission queue of the database the API uses, so
+// judging can run in separate processes or on separate machines from the API
+// (started with JUDGE_DISABLED=true). Any number of judges can share a queue:
+// each holds a lease on the submissions it judges, and submissions of judges
+// that die are requeued once their lease expires.
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	submissionApp "leetcode-api/internal/application/submission"
+	"leetcode-api/internal/config"
+	"leetcode-api/internal/infrastructure/persistence/sqlite"
+	"leetcode-api/internal/infrastructure/sandbox"
+)
+
+func main() {
+	// Become the sandbox helper when re-executed to run a submission
+	sandbox.Init()
+
+	// Open the API's database; the API creates and seeds it
+	dbPath := config.String("DB_PATH", "leetcode.db")
+	if _, err := os.Stat(dbPath); err != nil {
+		log.Fatal("Database not found, start the API first:", err)
+	}
+	db, err := sqlite.NewDB(dbPath)
+	if err != nil {
+		log.Fatal("Failed to connect database:", err)
+	}
+
+	// Initialize repositories
+	problemRepo := sqlite.NewProblemRepository(db)
+	submissionRepo := sqlite.NewSubmissionRepository(db)
+
+	// Initialize executor
+	codeExecutor, err := config.CodeExecutor()
+	if err != nil {
+		log.Fatal("Failed to initialize executor:", err)
+	}
+
+	judge := submissionApp.NewJudge(submissionRepo, problemRepo, submissionRepo, codeExecutor, nil, submissionApp.JudgeConfig{
+		ID:           os.Getenv("JUDGE_ID"),
+		Workers:      config.Int("JUDGE_QUEUE_WORKERS", 0),
+		PollInterval: time.Duration(config.Int("JUDGE_POLL_MS", 0)) * time.Millisecond,
+		Lease:        time.Duration(config.Int("JUDGE_LEASE_SECONDS", 0)) * time.Second,
+		MaxAttempts:  config.Int("JUDGE_MAX_ATTEMPTS", 0),
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("⚖️  Judge %s working on %s", judge.ID(), dbPath)
+	judge.Run(ctx)
+	log.Println("Judge exited")
+}
@@ -0,0 +1,77 @@
+// Package submission contains the handling of custom test inputs.
+package submission
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	problemDomain "leetcode-api/internal/domain/problem"
+	domain "leetcode-api/internal/domain/submission"
+	"leetcode-api/pkg/apperrors"
+)
+
+// Limits on the custom inputs of a single run
+const (
+	maxCustomInputs    = 10
+	maxCustomInputSize = 64 << 10
+)
+
+// customTestCases turns custom inputs into test cases after checking them
+// against the problem's signature or design spec. Their expected outputs come
+// from running the problem's reference solution on the same inputs.
+func (s *Service) customTestCases(ctx context.Context, problem *problemDomain.Problem, inputs []string) ([]problemDomain.TestCase, error) {
+	if !problem.AcceptsCustomInput() {
+		return nil, apperrors.NewValidation(fmt.Sprintf("problem %s does not accept custom test input", problem.Slug))
+	}
+	if len(inputs) > maxCustomInputs {
+		return nil, apperrors.NewValidation(fmt.Sprintf("at most %d custom inputs can be run at once", maxCustomInputs))
+	}
+
+	testCases := make([]problemDomain.TestCase, len(inputs))
+	for i, input := range inputs {
+		if len(input) > maxCustomInputSize {
+			return nil, apperrors.NewValidation(fmt.Sprintf("input %d is larger than %d bytes", i+1, maxCustomInputSize))
+		}
+		if err := problem.ValidateInput(input); err != nil {
+			return nil, apperrors.NewValidation(fmt.Sprintf("input %d: %v", i+1, err))
+		}
+		testCases[i] = problemDomain.TestCase{ProblemID: problem.ID, Input: input}
+	}
+
+	reference := problem.Reference
+	if err := reference.Validate(); err != nil {
+		return nil, apperrors.NewInternal("invalid reference solution", err)
+	}
+	if !s.executor.Supports(reference.Language) {
+		return nil, apperrors.NewInternal(fmt.Sprintf("reference solution language %s is unavailable", reference.Language), nil)
+	}
+
+	// The reference solution's output is wanted as it is, so the problem's
+	// checker, which may be a program, is replaced by an exact comparison
+	// with no expected output: Accepted and Wrong Answer both mean the run
+	// completed
+	referenceRun := *problem
+	referenceRun.Checker = &problemDomain.CheckerSpec{Kind: problemDomain.CheckerExact}
+
+	results := s.executor.Execute(ctx, &referenceRun, reference.Language, reference.Code, testCases, nil)
+	for i, result := range results {
+		switch result.Status {
+		case domain.StatusAccepted, domain.StatusWrong:
+			testCases[i].Expected = result.Actual
+		case domain.StatusCancelled:
+			return nil, apperrors.NewInternal("running the reference solution was cancelled", ctx.Err())
+		case domain.StatusCompile, domain.StatusInternal:
+			// The diagnostic may quote the reference solution, so it is only logged
+			log.Printf("reference solution of %s: %s: %s", problem.Slug, result.Status, result.Error)
+			return nil, apperrors.NewInternal("the reference solution could not be run", nil)
+		default:
+			// The reference solution handles every input within the constraints
+			return nil, apperrors.NewValidation(fmt.Sprintf(
+				"input %d: the reference solution ended with %s; check that the input meets the problem's constraints",
+				i+1, result.Status,
+			))
+		}
+	}
+	return testCases, nil
+}
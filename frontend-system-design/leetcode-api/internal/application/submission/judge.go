@@ -0,0 +1,249 @@
+// Package submission contains the judge that works through the submission queue.
+package submission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	problemDomain "leetcode-api/internal/domain/problem"
+	domain "leetcode-api/internal/domain/submission"
+)
+
+// JudgeConfig holds the judge's tunable settings. Zero values select the defaults.
+type JudgeConfig struct {
+	ID           string        // names the judge in leases; defaults to the host, process and a random suffix
+	Workers      int           // submissions judged at once
+	PollInterval time.Duration // how often idle workers look for submissions queued elsewhere
+	Lease        time.Duration // how long a claim lasts without a heartbeat
+	MaxAttempts  int           // claims a submission gets before it fails, as one that keeps killing its judge would
+}
+
+// Default settings used for zero JudgeConfig values
+const (
+	defaultJudgeWorkers = 2
+	defaultPollInterval = time.Second
+	defaultLease        = 30 * time.Second
+	defaultMaxAttempts  = 3
+)
+
+// Judge takes queued submissions and judges them against all of their
+// problem's test cases on a fixed number of workers. Several judges, in one
+// process or many, can share a queue: each claim is a lease the judge renews
+// with a heartbeat, and every judge requeues submissions whose lease expired.
+type Judge struct {
+	id             string
+	submissionRepo domain.Repository
+	problemRepo    problemDomain.Repository
+	queue          domain.Queue
+	executor       CodeExecutor
+	progress       *Progress // receives test results as they finish, if set
+	workers        int
+	pollInterval   time.Duration
+	lease          time.Duration
+	maxAttempts    int
+	wake           chan struct{}
+}
+
+// NewJudge creates a new Judge
+func NewJudge(
+	submissionRepo domain.Repository,
+	problemRepo problemDomain.Repository,
+	queue domain.Queue,
+	executor CodeExecutor,
+	progress *Progress,
+	cfg JudgeConfig,
+) *Judge {
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaultJudgeWorkers
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = defaultLease
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+	if cfg.ID == "" {
+		host, _ := os.Hostname()
+		cfg.ID = fmt.Sprintf("%s-%d-%s", host, os.Getpid(), uuid.New().String()[:8])
+	}
+	return &Judge{
+		id:             cfg.ID,
+		submissionRepo: submissionRepo,
+		problemRepo:    problemRepo,
+		queue:          queue,
+		executor:       executor,
+		progress:       progress,
+		workers:        cfg.Workers,
+		pollInterval:   cfg.PollInterval,
+		lease:          cfg.Lease,
+		maxAttempts:    cfg.MaxAttempts,
+		wake:           make(chan struct{}, 1),
+	}
+}
+
+// Notify wakes an idle worker to pick up a newly queued submission
+func (j *Judge) Notify() {
+	select {
+	case j.wake <- struct{}{}:
+	default:
+	}
+}
+
+// ID returns the name the judge takes leases under
+func (j *Judge) ID() string {
+	return j.id
+}
+
+// Run judges queued submissions until ctx is cancelled, then waits for the
+// workers to finish the submissions they hold
+func (j *Judge) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		j.requeueExpired(ctx)
+	}()
+	for i := 0; i < j.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			j.work(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+// work drains the queue, then sleeps until notified or the next poll
+func (j *Judge) work(ctx context.Context) {
+	ticker := time.NewTicker(j.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			submission, err := j.queue.Claim(ctx, j.id, j.lease)
+			if err != nil {
+				log.Printf("judge: claiming a submission: %v", err)
+				break
+			}
+			if submission == nil {
+				break
+			}
+			j.judge(ctx, submission)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-j.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// requeueExpired periodically returns submissions of dead judges to the
+// queue, failing those that used up their attempts
+func (j *Judge) requeueExpired(ctx context.Context) {
+	ticker := time.NewTicker(j.lease / 2)
+	defer ticker.Stop()
+
+	for {
+		requeued, failed, err := j.queue.RequeueExpired(ctx, j.maxAttempts)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("judge: requeueing expired submissions: %v", err)
+		}
+		if failed > 0 {
+			log.Printf("judge: failed %d submissions after %d attempts", failed, j.maxAttempts)
+		}
+		if requeued > 0 {
+			log.Printf("judge: requeued %d submissions with expired leases", requeued)
+			j.Notify()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// heartbeat renews the lease on a submission until ctx is done, calling
+// lost if another judge may have claimed the submission in the meantime
+func (j *Judge) heartbeat(ctx context.Context, id string, lost func()) {
+	ticker := time.NewTicker(j.lease / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := j.queue.Renew(ctx, id, j.id, j.lease)
+		if errors.Is(err, domain.ErrLeaseLost) {
+			log.Printf("judge: lost the lease on submission %s", id)
+			lost()
+			return
+		}
+		if err != nil && ctx.Err() == nil {
+			log.Printf("judge: renewing the lease on submission %s: %v", id, err)
+		}
+	}
+}
+
+// judge runs a claimed submission and stores its verdict
+func (j *Judge) judge(ctx context.Context, submission *domain.Submission) {
+	// Losing the lease stops the run, whose verdict could no longer be stored
+	runCtx, stopRun := context.WithCancel(ctx)
+	defer stopRun()
+	var leaseLost atomic.Bool
+	heartbeatCtx, stopHeartbeat := context.WithCancel(context.Background())
+	defer stopHeartbeat()
+	go j.heartbeat(heartbeatCtx, submission.ID, func() {
+		leaseLost.Store(true)
+		stopRun()
+	})
+
+	var onResult domain.ResultFunc
+	if j.progress != nil {
+		j.progress.start(submission.ID)
+		// Watchers are released only once the verdict they will load is stored
+		defer j.progress.finish(submission.ID)
+	}
+
+	problem, err := j.problemRepo.FindByID(runCtx, submission.ProblemID)
+	if err != nil {
+		submission.Fail(fmt.Sprintf("loading problem %d: %v", submission.ProblemID, err))
+	} else {
+		if j.progress != nil {
+			total := len(problem.TestCases)
+			onResult = func(index int, result domain.TestResult) {
+				j.progress.publish(submission.ID, TestEvent{Index: index, Total: total, Result: result})
+			}
+		}
+		results := j.executor.Execute(runCtx, problem, submission.Language, submission.Code, problem.TestCases, onResult)
+		submission.SetResults(results)
+	}
+
+	// The verdict is stored even while shutting down; shutting down cancels
+	// the run, and the submission is recorded as cancelled
+	stopHeartbeat()
+	if leaseLost.Load() {
+		return
+	}
+	if err := j.queue.Complete(context.Background(), submission, j.id); err != nil {
+		log.Printf("judge: storing submission %s: %v", submission.ID, err)
+	}
+}
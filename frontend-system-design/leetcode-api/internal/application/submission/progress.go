@@ -0,0 +1,199 @@
+// Package submission contains the live progress of submissions being judged.
+package submission
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	domain "leetcode-api/internal/domain/submission"
+)
+
+// watcherBuffer is how many test events a slow watcher may fall behind.
+// Events it misses are recovered from the stored verdict.
+const watcherBuffer = 64
+
+// TestEvent reports that one test of a submission finished
+type TestEvent struct {
+	Index  int
+	Total  int
+	Result domain.TestResult
+}
+
+// Event is a step of a submission's progress: a finished test or, last,
+// the final verdict
+type Event struct {
+	Test    *TestEvent
+	Verdict *domain.Submission
+}
+
+// Progress fans the test results of submissions judged in this process out
+// to their watchers
+type Progress struct {
+	mu   sync.Mutex
+	runs map[string]*progressRun
+}
+
+// progressRun holds the events of one submission
+type progressRun struct {
+	running  bool
+	tests    []TestEvent // published so far, replayed to new watchers
+	watchers map[chan TestEvent]struct{}
+}
+
+// NewProgress creates an empty Progress
+func NewProgress() *Progress {
+	return &Progress{runs: make(map[string]*progressRun)}
+}
+
+// run returns the entry of a submission, creating it if needed. The caller holds mu.
+func (p *Progress) run(id string) *progressRun {
+	run, ok := p.runs[id]
+	if !ok {
+		run = &progressRun{watchers: make(map[chan TestEvent]struct{})}
+		p.runs[id] = run
+	}
+	return run
+}
+
+// start marks a submission as being judged
+func (p *Progress) start(id string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	run := p.run(id)
+	run.running = true
+	run.tests = nil
+}
+
+// publish sends a finished test to the submission's watchers
+func (p *Progress) publish(id string, event TestEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	run := p.run(id)
+	run.tests = append(run.tests, event)
+	for ch := range run.watchers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// finish closes the watchers of a submission whose verdict has been stored
+func (p *Progress) finish(id string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if run, ok := p.runs[id]; ok {
+		for ch := range run.watchers {
+			close(ch)
+		}
+		delete(p.runs, id)
+	}
+}
+
+// subscribe returns the tests of a submission published so far and a
+// channel of those that follow, which closes when the submission finishes
+func (p *Progress) subscribe(id string) ([]TestEvent, <-chan TestEvent, func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	run := p.run(id)
+	ch := make(chan TestEvent, watcherBuffer)
+	run.watchers[ch] = struct{}{}
+	replay := append([]TestEvent(nil), run.tests...)
+
+	unsubscribe := func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if current, ok := p.runs[id]; ok && current == run {
+			delete(run.watchers, ch)
+			if len(run.watchers) == 0 && !run.running {
+				delete(p.runs, id)
+			}
+		}
+	}
+	return replay, ch, unsubscribe
+}
+
+// Watch streams the progress of a submission: an event for every test as it
+// finishes, then the verdict. Tests that finished before the call are sent
+// first. Submissions judged in another process are followed by polling the
+// database. The channel closes after the verdict or when ctx is done.
+func (s *Service) Watch(ctx context.Context, id string) (<-chan Event, error) {
+	var replay []TestEvent
+	var tests <-chan TestEvent
+	unsubscribe := func() {}
+	// Subscribing before loading means no event falls between the two
+	if s.progress != nil {
+		replay, tests, unsubscribe = s.progress.subscribe(id)
+	}
+
+	submission, err := s.submissionRepo.FindByID(ctx, id)
+	if err != nil {
+		unsubscribe()
+		return nil, err
+	}
+
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		defer unsubscribe()
+
+		send := func(event Event) bool {
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		sent := make(map[int]bool)
+		sendTest := func(test TestEvent) bool {
+			if sent[test.Index] {
+				return true
+			}
+			sent[test.Index] = true
+			return send(Event{Test: &test})
+		}
+
+		for _, test := range replay {
+			if !sendTest(test) {
+				return
+			}
+		}
+
+		ticker := time.NewTicker(defaultPollInterval)
+		defer ticker.Stop()
+		for {
+			if submission.Status.Finished() {
+				// Fill in tests that were judged elsewhere or dropped for a slow watcher
+				for i, result := range submission.Results {
+					if !sendTest(TestEvent{Index: i, Total: len(submission.Results), Result: result}) {
+						return
+					}
+				}
+				send(Event{Verdict: submission})
+				return
+			}
+
+			select {
+			case test, ok := <-tests:
+				if ok {
+					if !sendTest(test) {
+						return
+					}
+					continue
+				}
+				// The verdict has been stored
+				tests = nil
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+
+			if submission, err = s.submissionRepo.FindByID(ctx, id); err != nil {
+				return
+			}
+		}
+	}()
+	return events, nil
+}
@@ -3,16 +3,21 @@ package submission
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/uuid"
 
 	problemDomain "leetcode-api/internal/domain/problem"
 	domain "leetcode-api/internal/domain/submission"
+	"leetcode-api/pkg/apperrors"
 )
 
-// CodeExecutor interface for running code
+// CodeExecutor interface for running code. Cancelling the context passed to
+// Execute stops the run and reports its unfinished tests as cancelled.
 type CodeExecutor interface {
-	Execute(language, code string, testCases []problemDomain.TestCase) []domain.TestResult
+	Execute(ctx context.Context, problem *problemDomain.Problem, language, code string, testCases []problemDomain.TestCase, onResult domain.ResultFunc) []domain.TestResult
+	Supports(language string) bool
+	Languages() []domain.Language
 }
 
 // Service provides submission-related use cases
@@ -20,6 +25,8 @@ type Service struct {
 	submissionRepo domain.Repository
 	problemRepo    problemDomain.Repository
 	executor       CodeExecutor
+	onQueued       func()    // called after a submission is queued, if set
+	progress       *Progress // live results of submissions judged in this process, if any
 }
 
 // NewService creates a new Submission service
@@ -35,55 +42,92 @@ func NewService(
 	}
 }
 
-// RunCode runs code against example (visible) test cases
-func (s *Service) RunCode(ctx context.Context, problemID uint, language, code string) (*domain.Submission, error) {
+// RunCode runs code against example (visible) test cases, or against the
+// custom inputs when any are given. The expected outputs of custom inputs
+// are those of the problem's reference solution.
+func (s *Service) RunCode(ctx context.Context, problemID uint, language, code string, customInputs []string) (*domain.Submission, error) {
+	if err := s.validateLanguage(language); err != nil {
+		return nil, err
+	}
+
 	// Get problem with visible test cases only
 	problem, err := s.problemRepo.FindByID(ctx, problemID)
 	if err != nil {
 		return nil, err
 	}
 
-	visibleTests := problem.VisibleTestCases()
+	testCases := problem.VisibleTestCases()
+	if len(customInputs) > 0 {
+		if testCases, err = s.customTestCases(ctx, problem, customInputs); err != nil {
+			return nil, err
+		}
+	}
 
 	// Create submission
 	submission := domain.NewSubmission(uuid.New().String(), problemID, language, code)
 
-	// Execute code
-	results := s.executor.Execute(language, code, visibleTests)
+	// Execute code; a client that disconnects cancels the run
+	results := s.executor.Execute(ctx, problem, language, code, testCases, nil)
 	submission.SetResults(results)
 
-	// Save submission
-	if err := s.submissionRepo.Create(ctx, submission); err != nil {
+	// Save submission, recording cancelled runs too
+	if err := s.submissionRepo.Create(context.WithoutCancel(ctx), submission); err != nil {
 		return nil, err
 	}
 
 	return submission, nil
 }
 
-// SubmitCode runs code against all test cases (including hidden)
+// SubmitCode queues code to be judged against all test cases (including hidden).
+// The submission is returned as Pending; a Judge fills in its verdict.
 func (s *Service) SubmitCode(ctx context.Context, problemID uint, language, code string) (*domain.Submission, error) {
-	// Get problem with ALL test cases
-	problem, err := s.problemRepo.FindByID(ctx, problemID)
-	if err != nil {
+	if err := s.validateLanguage(language); err != nil {
 		return nil, err
 	}
 
-	// Create submission
-	submission := domain.NewSubmission(uuid.New().String(), problemID, language, code)
-
-	// Execute code against all tests
-	results := s.executor.Execute(language, code, problem.TestCases)
-	submission.SetResults(results)
+	// Make sure the problem exists before queueing
+	if _, err := s.problemRepo.FindByID(ctx, problemID); err != nil {
+		return nil, err
+	}
 
-	// Save submission
+	// Saving a pending submission puts it on the queue
+	submission := domain.NewSubmission(uuid.New().String(), problemID, language, code)
 	if err := s.submissionRepo.Create(ctx, submission); err != nil {
 		return nil, err
 	}
 
+	if s.onQueued != nil {
+		s.onQueued()
+	}
 	return submission, nil
 }
 
+// SetProgress lets Watch follow the submissions judged by a Judge in this
+// process as their tests finish, rather than polling for the verdict
+func (s *Service) SetProgress(progress *Progress) {
+	s.progress = progress
+}
+
+// OnQueued registers a function called after each submission is queued,
+// such as Judge.Notify for a judge running in the same process
+func (s *Service) OnQueued(notify func()) {
+	s.onQueued = notify
+}
+
 // GetSubmission returns a submission by ID
 func (s *Service) GetSubmission(ctx context.Context, id string) (*domain.Submission, error) {
 	return s.submissionRepo.FindByID(ctx, id)
 }
+
+// Languages returns the languages submissions can be written in
+func (s *Service) Languages() []domain.Language {
+	return s.executor.Languages()
+}
+
+// validateLanguage rejects languages the executor cannot judge
+func (s *Service) validateLanguage(language string) error {
+	if !s.executor.Supports(language) {
+		return apperrors.NewValidation(fmt.Sprintf("unsupported language: %s", language))
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Package config reads the settings shared by the API, judge and judge
+// server binaries from the environment and builds the executors they use.
+package config
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	submissionApp "leetcode-api/internal/application/submission"
+	"leetcode-api/internal/infrastructure/executor"
+	"leetcode-api/internal/infrastructure/sandbox"
+)
+
+// Int reads an integer setting from the environment
+func Int(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// String reads a string setting from the environment
+func String(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// Bool reads a boolean setting from the environment, false when unset
+func Bool(key string) bool {
+	value, _ := strconv.ParseBool(os.Getenv(key))
+	return value
+}
+
+// Languages reads the language runners from the file named by
+// JUDGE_LANGUAGES, falling back to the built-in definitions
+func Languages() (*executor.Registry, error) {
+	if path := os.Getenv("JUDGE_LANGUAGES"); path != "" {
+		return executor.LoadRegistry(path)
+	}
+	return executor.DefaultRegistry()
+}
+
+// LocalExecutor creates an executor that runs code in this process's
+// sandbox. Without one it fails, unless JUDGE_ALLOW_UNSANDBOXED explicitly
+// allows compiling and running submissions unconfined.
+func LocalExecutor() (*executor.CodeExecutor, error) {
+	sb, err := sandbox.New(sandbox.DefaultIDs())
+	if err != nil {
+		if !Bool("JUDGE_ALLOW_UNSANDBOXED") {
+			return nil, fmt.Errorf("sandbox unavailable (set JUDGE_ALLOW_UNSANDBOXED=1 to compile and run submissions unconfined): %w", err)
+		}
+		log.Printf("⚠️  Sandbox unavailable, submissions will compile and run unconfined: %v", err)
+	}
+	languages, err := Languages()
+	if err != nil {
+		return nil, fmt.Errorf("loading languages: %w", err)
+	}
+	for _, l := range languages.Languages() {
+		if !l.Available {
+			log.Printf("⚠️  %s toolchain not found, submissions in it will be rejected", l.Name)
+		}
+	}
+	return executor.New(executor.Config{
+		Timeout:     5 * time.Second,
+		StdoutLimit: Int("JUDGE_STDOUT_LIMIT", 0),
+		Workers:     Int("JUDGE_WORKERS", 0),
+		Languages:   languages,
+	}, sb), nil
+}
+
+// CodeExecutor judges code on the servers listed in JUDGE_EXECUTOR_URLS,
+// or in this process when none are
+func CodeExecutor() (submissionApp.CodeExecutor, error) {
+	urls := os.Getenv("JUDGE_EXECUTOR_URLS")
+	if urls == "" {
+		local, err := LocalExecutor()
+		if err != nil {
+			return nil, err
+		}
+		return local, nil
+	}
+
+	remote, err := executor.NewRemote(executor.RemoteConfig{
+		URLs:  strings.Split(urls, ","),
+		Token: os.Getenv("JUDGE_TOKEN"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := remote.CheckHealth(context.Background()); err != nil {
+		log.Printf("⚠️  %v", err)
+	}
+	go remote.MonitorHealth(context.Background())
+	log.Printf("🔗 Judging on %s", urls)
+	return remote, nil
+}
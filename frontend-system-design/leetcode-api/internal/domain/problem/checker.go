@@ -0,0 +1,54 @@
+// Package problem contains the output checker specification.
+package problem
+
+import "fmt"
+
+// CheckerKind selects how a program's result is compared with the expected output
+type CheckerKind string
+
+const (
+	CheckerExact          CheckerKind = "exact"                 // equal text after trimming whitespace
+	CheckerTokens         CheckerKind = "tokens"                // equal whitespace-separated tokens, as contest judges compare
+	CheckerJSON           CheckerKind = "json"                  // equal JSON values, ignoring formatting
+	CheckerUnorderedList  CheckerKind = "unordered-list"        // JSON arrays equal up to element order
+	CheckerUnorderedLists CheckerKind = "unordered-nested-list" // arrays of arrays equal up to the order at both levels
+	CheckerFloat          CheckerKind = "float"                 // JSON values whose numbers match within a tolerance
+	CheckerProgram        CheckerKind = "program"               // a checker program written by the problem author decides
+)
+
+// DefaultFloatTolerance is the absolute or relative error float checkers accept by default
+const DefaultFloatTolerance = 1e-5
+
+// CheckerSpec declares how a problem's outputs are judged. Problems without
+// one are compared as JSON when judged through a signature, by tokens in io
+// mode, and exactly otherwise.
+//
+// A checker program reads {"input": ..., "expected": ..., "actual": ...}
+// from stdin, where all three values are strings, and exits with status 0 to
+// accept the output or 1 to reject it. Any other exit is a judge error.
+type CheckerSpec struct {
+	Kind      CheckerKind `json:"kind"`
+	Tolerance float64     `json:"tolerance,omitempty"` // for float checkers; 0 uses DefaultFloatTolerance
+	Language  string      `json:"language,omitempty"`  // language of a checker program
+	Code      string      `json:"code,omitempty"`      // source of a checker program
+}
+
+// Validate checks that the spec names a known checker with the settings it needs
+func (c CheckerSpec) Validate() error {
+	switch c.Kind {
+	case CheckerExact, CheckerTokens, CheckerJSON, CheckerUnorderedList, CheckerUnorderedLists:
+		return nil
+	case CheckerFloat:
+		if c.Tolerance < 0 {
+			return fmt.Errorf("negative float tolerance %g", c.Tolerance)
+		}
+		return nil
+	case CheckerProgram:
+		if c.Language == "" || c.Code == "" {
+			return fmt.Errorf("checker program needs a language and code")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown checker kind %q", c.Kind)
+	}
+}
@@ -0,0 +1,49 @@
+// Package problem contains the specification for multi-threaded problems.
+package problem
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ConcurrencySpec describes how a concurrency problem is driven and judged.
+// A test input is a JSON array naming the method each thread calls, such as
+// ["first","third","second"]. Every method receives a callback that emits
+// the method's token, and the emitted tokens form the output of a run.
+type ConcurrencySpec struct {
+	ClassName string            `json:"className"`
+	Tokens    map[string]string `json:"tokens"` // method name -> token its callback emits
+	Runs      int               `json:"runs"`   // repetitions per test case, to surface races
+	// Invariant, when set, requires every consecutive group of len(Invariant)
+	// tokens to be a permutation of it. Otherwise a run must match Expected.
+	Invariant string `json:"invariant,omitempty"`
+}
+
+// Methods returns the thread entry methods in a stable order
+func (s ConcurrencySpec) Methods() []string {
+	methods := make([]string, 0, len(s.Tokens))
+	for m := range s.Tokens {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// Validate checks that the spec can be used to generate a harness
+func (s ConcurrencySpec) Validate() error {
+	if !identifierPattern.MatchString(s.ClassName) {
+		return fmt.Errorf("invalid class name %q", s.ClassName)
+	}
+	if len(s.Tokens) == 0 {
+		return fmt.Errorf("concurrency spec declares no methods")
+	}
+	for method, token := range s.Tokens {
+		if !identifierPattern.MatchString(method) {
+			return fmt.Errorf("invalid method name %q", method)
+		}
+		if s.Invariant != "" && len(token) != 1 {
+			return fmt.Errorf("invariant checks need single-character tokens, %s emits %q", method, token)
+		}
+	}
+	return nil
+}
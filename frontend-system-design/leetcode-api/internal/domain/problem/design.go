@@ -0,0 +1,108 @@
+// Package problem contains the specification for design problems.
+package problem
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DesignSpec describes a design problem, whose solution is a class such as
+// LRUCache. A test input holds LeetCode's two arrays, the operations and the
+// arguments of each, such as [["LRUCache","put","get"],[[2],[1,1],[1]]],
+// where the first operation constructs the object. The output is the array
+// of what each call returned, with null for the constructor and void methods.
+type DesignSpec struct {
+	ClassName   string      `json:"className"`
+	Constructor []Param     `json:"constructor"`
+	Methods     []Signature `json:"methods"` // FunctionName holds the method name
+}
+
+// Method returns the method with the given name
+func (s DesignSpec) Method(name string) (Signature, bool) {
+	for _, m := range s.Methods {
+		if m.FunctionName == name {
+			return m, true
+		}
+	}
+	return Signature{}, false
+}
+
+// Validate checks that the spec can be used to generate a harness
+func (s DesignSpec) Validate() error {
+	if !identifierPattern.MatchString(s.ClassName) {
+		return fmt.Errorf("invalid class name %q", s.ClassName)
+	}
+	constructor := Signature{FunctionName: s.ClassName, Params: s.Constructor, ReturnType: TypeVoid}
+	if err := constructor.Validate(); err != nil {
+		return fmt.Errorf("constructor: %w", err)
+	}
+	if len(s.Methods) == 0 {
+		return fmt.Errorf("design spec declares no methods")
+	}
+	seen := make(map[string]bool, len(s.Methods))
+	for _, m := range s.Methods {
+		if err := m.Validate(); err != nil {
+			return fmt.Errorf("method %s: %w", m.FunctionName, err)
+		}
+		if m.OutputParam != "" {
+			return fmt.Errorf("method %s cannot be judged by a parameter", m.FunctionName)
+		}
+		if m.FunctionName == s.ClassName {
+			return fmt.Errorf("method %s has the class's name", m.FunctionName)
+		}
+		if seen[m.FunctionName] {
+			return fmt.Errorf("duplicate method %s", m.FunctionName)
+		}
+		seen[m.FunctionName] = true
+	}
+	return nil
+}
+
+// ValidateInput checks that a test input is a valid operation sequence:
+// the constructor first, then known methods, each with arguments matching
+// its parameter types
+func (s DesignSpec) ValidateInput(input string) error {
+	decoder := json.NewDecoder(strings.NewReader(input))
+	decoder.UseNumber()
+	var calls [][]interface{}
+	if err := decoder.Decode(&calls); err != nil || len(calls) != 2 {
+		return fmt.Errorf("input must hold the operation and argument arrays")
+	}
+	if decoder.More() {
+		return fmt.Errorf("unexpected data after the operation and argument arrays")
+	}
+	operations, args := calls[0], calls[1]
+	if len(operations) == 0 {
+		return fmt.Errorf("no operations")
+	}
+	if len(operations) != len(args) {
+		return fmt.Errorf("%d operations but %d argument lists", len(operations), len(args))
+	}
+
+	for i, op := range operations {
+		name, _ := op.(string)
+		params := s.Constructor
+		switch {
+		case i == 0 && name != s.ClassName:
+			return fmt.Errorf("operation 1 must construct %s", s.ClassName)
+		case i > 0:
+			m, ok := s.Method(name)
+			if !ok {
+				return fmt.Errorf("operation %d: unknown method %v", i+1, op)
+			}
+			params = m.Params
+		}
+
+		values, ok := args[i].([]interface{})
+		if !ok || len(values) != len(params) {
+			return fmt.Errorf("operation %d: %s takes %d arguments", i+1, name, len(params))
+		}
+		for j, p := range params {
+			if err := checkValue(p.Type, values[j]); err != nil {
+				return fmt.Errorf("operation %d: argument %s: %w", i+1, p.Name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -2,6 +2,9 @@
 package problem
 
 import (
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -25,6 +28,9 @@ const (
 	CategoryJavaScript  Category = "javascript"
 )
 
+// DefaultMemoryLimit is the memory limit in MB for problems that do not set their own
+const DefaultMemoryLimit = 256
+
 // Topic represents a problem topic/tag
 type Topic struct {
 	ID    uint
@@ -43,7 +49,16 @@ type Problem struct {
 	Description    string
 	Examples       string
 	Constraints    string
-	StarterCode    string // JSON map of language -> code
+	StarterCode    string             // JSON map of language -> code
+	Signature      *Signature         // nil for problems not judged as a function call
+	Database       *DatabaseSpec      // set for database-category problems
+	Concurrency    *ConcurrencySpec   // set for concurrency-category problems
+	Design         *DesignSpec        // set for problems whose solution is a class
+	Interactive    *InteractiveSpec   // set for problems whose solution talks to a judge program
+	IOMode         bool               // solutions are full programs reading the test input from stdin and printing the answer
+	Checker        *CheckerSpec       // nil uses the default comparison
+	Reference      *ReferenceSolution // computes expected outputs for custom test inputs, if set
+	MemoryLimit    int                // peak memory per test case in MB; 0 uses DefaultMemoryLimit
 	AcceptanceRate float64
 	Submissions    int
 	Accepted       int
@@ -54,15 +69,45 @@ type Problem struct {
 	UpdatedAt      time.Time
 }
 
+// DatabaseSpec describes how a database problem is judged.
+// Test case inputs hold fixture rows in LeetCode's format,
+// {"headers": {"Table": ["col", ...]}, "rows": {"Table": [[...], ...]}},
+// and expected outputs hold {"headers": ["col", ...], "values": [[...], ...]}.
+type DatabaseSpec struct {
+	Schema  string `json:"schema"`  // DDL run on a fresh database before loading fixtures
+	Ordered bool   `json:"ordered"` // whether row order is part of the expected result
+}
+
 // TestCase represents a test case for a problem
 type TestCase struct {
 	ID        uint
 	ProblemID uint
 	Input     string
 	Expected  string
+	Files     map[string]string // fixture files written to the working directory, by name
 	IsHidden  bool
 }
 
+// DisplayInput returns the input shown to users, falling back to the
+// fixture files for test cases that are driven by files only
+func (tc TestCase) DisplayInput() string {
+	if tc.Input != "" || len(tc.Files) == 0 {
+		return tc.Input
+	}
+
+	names := make([]string, 0, len(tc.Files))
+	for name := range tc.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + ":\n" + tc.Files[name]
+	}
+	return strings.Join(parts, "\n")
+}
+
 // NewProblem creates a new Problem entity
 func NewProblem(slug, title string, difficulty Difficulty, category Category, description string) *Problem {
 	return &Problem{
@@ -102,6 +147,36 @@ func (p *Problem) VisibleTestCases() []TestCase {
 	return visible
 }
 
+// AcceptsCustomInput reports whether solutions can be run on inputs users
+// write themselves: the problem is judged in io mode or through a signature
+// or design spec the inputs are checked against, and has a reference
+// solution to compute their expected outputs
+func (p *Problem) AcceptsCustomInput() bool {
+	return (p.IOMode || p.Signature != nil || p.Design != nil) && p.Concurrency == nil && p.Interactive == nil && p.Reference != nil
+}
+
+// ValidateInput checks a custom test input against the problem's design
+// spec or signature. Any text is a valid input in io mode.
+func (p *Problem) ValidateInput(input string) error {
+	switch {
+	case p.IOMode:
+		return nil
+	case p.Design != nil:
+		return p.Design.ValidateInput(input)
+	case p.Signature != nil:
+		return p.Signature.ValidateInput(input)
+	}
+	return fmt.Errorf("problem %s does not accept custom test input", p.Slug)
+}
+
+// MemoryLimitKB returns the problem's effective memory limit in KB
+func (p *Problem) MemoryLimitKB() int {
+	if p.MemoryLimit > 0 {
+		return p.MemoryLimit * 1024
+	}
+	return DefaultMemoryLimit * 1024
+}
+
 // CalculateAcceptanceRate computes acceptance rate
 func (p *Problem) CalculateAcceptanceRate() float64 {
 	if p.Submissions == 0 {
@@ -0,0 +1,37 @@
+package problem
+
+import "testing"
+
+func TestProblemValidateInput(t *testing.T) {
+	signature := &Signature{FunctionName: "isPalindrome", Params: []Param{{"x", TypeInteger}}, ReturnType: TypeBoolean}
+	design := &DesignSpec{
+		ClassName: "MinStack",
+		Methods: []Signature{
+			{FunctionName: "push", Params: []Param{{"val", TypeInteger}}, ReturnType: TypeVoid},
+			{FunctionName: "getMin", ReturnType: TypeInteger},
+		},
+	}
+	tests := []struct {
+		name    string
+		problem Problem
+		input   string
+		wantErr bool
+	}{
+		{"io mode accepts any text", Problem{IOMode: true}, "3\n1 2 3", false},
+		{"signature", Problem{Signature: signature}, `[121]`, false},
+		{"signature type mismatch", Problem{Signature: signature}, `["121"]`, true},
+		{"design", Problem{Design: design}, `[["MinStack","push","getMin"],[[],[1],[]]]`, false},
+		{"design unknown method", Problem{Design: design}, `[["MinStack","pop"],[[],[]]]`, true},
+		{"design without constructor", Problem{Design: design}, `[["push"],[[1]]]`, true},
+		{"design argument count", Problem{Design: design}, `[["MinStack","push"],[[],[]]]`, true},
+		{"no input format", Problem{Slug: "sql"}, `[]`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.problem.ValidateInput(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateInput(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Package problem contains the specification for interactive problems.
+package problem
+
+import "fmt"
+
+// InteractiveSpec describes an interactive problem, such as guessing a hidden
+// number, whose solution is a full program that talks to a judge program:
+// each one's stdout is connected to the other's stdin. The test input is
+// the judge's secret and never reaches the solution.
+//
+// The judge program reads {"input": ..., "expected": ..., "queryLimit": ...}
+// from file descriptor 3, where input and expected are strings, and exits
+// with status 0 to accept the solution or 1 to reject it, explaining why on
+// stderr. Any other exit is a judge error. Enforcing the query limit is up
+// to the judge, which knows what counts as a query.
+type InteractiveSpec struct {
+	Language   string `json:"language"`   // language of the judge program
+	Code       string `json:"code"`       // source of the judge program
+	QueryLimit int    `json:"queryLimit"` // queries a solution may make per test case
+}
+
+// Validate checks that the spec declares a judge program and a query budget
+func (s InteractiveSpec) Validate() error {
+	if s.Language == "" || s.Code == "" {
+		return fmt.Errorf("interactive problem needs a judge language and code")
+	}
+	if s.QueryLimit <= 0 {
+		return fmt.Errorf("interactive problem needs a positive query limit, got %d", s.QueryLimit)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+// Package problem contains the reference solution type.
+package problem
+
+import "fmt"
+
+// ReferenceSolution is the problem author's solution. Running it on a custom
+// test input gives the expected output that a user's output is shown against.
+type ReferenceSolution struct {
+	Language string `json:"language"`
+	Code     string `json:"code"`
+}
+
+// Validate checks that the solution names a language and has code
+func (r ReferenceSolution) Validate() error {
+	if r.Language == "" || r.Code == "" {
+		return fmt.Errorf("reference solution needs a language and code")
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+// Package problem contains the function signature metadata used to judge solutions.
+package problem
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValueType is a LeetCode-style type name such as "integer" or "string[][]"
+type ValueType string
+
+const (
+	TypeInteger   ValueType = "integer"
+	TypeLong      ValueType = "long"
+	TypeDouble    ValueType = "double"
+	TypeBoolean   ValueType = "boolean"
+	TypeString    ValueType = "string"
+	TypeCharacter ValueType = "character"
+	TypeListNode  ValueType = "ListNode"
+	TypeTreeNode  ValueType = "TreeNode"
+	TypeVoid      ValueType = "void"
+)
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// IsArray reports whether the type is an array of another type
+func (t ValueType) IsArray() bool {
+	return strings.HasSuffix(string(t), "[]")
+}
+
+// Elem returns the element type of an array type
+func (t ValueType) Elem() ValueType {
+	return ValueType(strings.TrimSuffix(string(t), "[]"))
+}
+
+// Base returns the innermost element type
+func (t ValueType) Base() ValueType {
+	for t.IsArray() {
+		t = t.Elem()
+	}
+	return t
+}
+
+// IsNode reports whether the type is a linked list or binary tree node,
+// which tests give in LeetCode's array forms
+func (t ValueType) IsNode() bool {
+	return t == TypeListNode || t == TypeTreeNode
+}
+
+// ArrayOf returns the array type of t
+func ArrayOf(t ValueType) ValueType {
+	return t + "[]"
+}
+
+// Validate checks that the type is built from known base types
+func (t ValueType) Validate() error {
+	switch t.Base() {
+	case TypeInteger, TypeLong, TypeDouble, TypeBoolean, TypeString, TypeCharacter,
+		TypeListNode, TypeTreeNode:
+		return nil
+	case TypeVoid:
+		if t.IsArray() {
+			return fmt.Errorf("invalid type %q", t)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown type %q", t)
+}
+
+// Param is a single parameter of the entry point
+type Param struct {
+	Name string    `json:"name"`
+	Type ValueType `json:"type"`
+}
+
+// Signature describes the function a solution must define. Functions that
+// modify an argument in place, such as moveZeroes, return void and name the
+// argument in OutputParam; its value after the call is judged instead of
+// the return value.
+type Signature struct {
+	FunctionName string    `json:"functionName"`
+	Params       []Param   `json:"params"`
+	ReturnType   ValueType `json:"returnType"`
+	OutputParam  string    `json:"outputParam,omitempty"`
+}
+
+// Output returns the index of the parameter judged after the call, or -1
+// when the return value is judged
+func (s Signature) Output() int {
+	if s.OutputParam == "" {
+		return -1
+	}
+	for i, p := range s.Params {
+		if p.Name == s.OutputParam {
+			return i
+		}
+	}
+	return -1
+}
+
+// Validate checks that the signature can be used to generate a driver
+func (s Signature) Validate() error {
+	if !identifierPattern.MatchString(s.FunctionName) {
+		return fmt.Errorf("invalid function name %q", s.FunctionName)
+	}
+	for _, p := range s.Params {
+		if !identifierPattern.MatchString(p.Name) {
+			return fmt.Errorf("invalid parameter name %q", p.Name)
+		}
+		if p.Type == TypeVoid {
+			return fmt.Errorf("parameter %s cannot be void", p.Name)
+		}
+		if err := p.Type.Validate(); err != nil {
+			return fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+	}
+	if s.OutputParam != "" {
+		i := s.Output()
+		if i < 0 {
+			return fmt.Errorf("output parameter %s is not a parameter", s.OutputParam)
+		}
+		if s.ReturnType != TypeVoid {
+			return fmt.Errorf("function judged by its parameter %s must return void", s.OutputParam)
+		}
+		// Only arrays and nodes can be modified where the caller sees them
+		if t := s.Params[i].Type; !t.IsArray() && !t.IsNode() {
+			return fmt.Errorf("output parameter %s has type %s, which cannot be modified in place", s.OutputParam, t)
+		}
+	}
+	return s.ReturnType.Validate()
+}
+
+// ValidateInput checks that a test input, a JSON array holding one argument
+// per parameter, matches the signature's parameter types. Linked lists and
+// trees are given in LeetCode's array form, trees with null for missing nodes.
+func (s Signature) ValidateInput(input string) error {
+	decoder := json.NewDecoder(strings.NewReader(input))
+	decoder.UseNumber()
+	var args []interface{}
+	if err := decoder.Decode(&args); err != nil {
+		return fmt.Errorf("input must be a JSON array of arguments: %w", err)
+	}
+	if decoder.More() {
+		return fmt.Errorf("unexpected data after the argument array")
+	}
+	if len(args) != len(s.Params) {
+		return fmt.Errorf("expected %d arguments, got %d", len(s.Params), len(args))
+	}
+	for i, p := range s.Params {
+		if err := checkValue(p.Type, args[i]); err != nil {
+			return fmt.Errorf("argument %s: %w", p.Name, err)
+		}
+	}
+	return nil
+}
+
+// checkValue checks that a decoded JSON value has type t
+func checkValue(t ValueType, v interface{}) error {
+	if t.IsArray() {
+		items, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("expected %s", t)
+		}
+		for i, item := range items {
+			if err := checkValue(t.Elem(), item); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		return nil
+	}
+
+	switch t {
+	case TypeInteger:
+		return checkInteger(v, math.MinInt32, math.MaxInt32, t)
+	case TypeLong:
+		return checkInteger(v, math.MinInt64, math.MaxInt64, t)
+	case TypeDouble:
+		if n, ok := v.(json.Number); ok {
+			if _, err := n.Float64(); err == nil {
+				return nil
+			}
+		}
+	case TypeBoolean:
+		if _, ok := v.(bool); ok {
+			return nil
+		}
+	case TypeString:
+		if _, ok := v.(string); ok {
+			return nil
+		}
+	case TypeCharacter:
+		if s, ok := v.(string); ok && utf8.RuneCountInString(s) == 1 {
+			return nil
+		}
+	case TypeListNode:
+		// A list is the array of its values
+		return checkValue(ArrayOf(TypeInteger), v)
+	case TypeTreeNode:
+		// A tree is its level order, with null for missing children
+		items, ok := v.([]interface{})
+		if !ok {
+			break
+		}
+		for i, item := range items {
+			if item == nil {
+				continue
+			}
+			if err := checkValue(TypeInteger, item); err != nil {
+				return fmt.Errorf("node %d: %w", i, err)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("expected %s", t)
+}
+
+// checkInteger checks that v is a whole number within [min, max]
+func checkInteger(v interface{}, min, max int64, t ValueType) error {
+	n, ok := v.(json.Number)
+	if !ok {
+		return fmt.Errorf("expected %s", t)
+	}
+	i, err := strconv.ParseInt(n.String(), 10, 64)
+	if err != nil || i < min || i > max {
+		return fmt.Errorf("expected %s, got %s", t, n)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package problem
+
+import "testing"
+
+func TestSignatureValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		sig     Signature
+		wantErr bool
+	}{
+		{
+			name: "function",
+			sig:  Signature{FunctionName: "twoSum", Params: []Param{{"nums", "integer[]"}, {"target", TypeInteger}}, ReturnType: "integer[]"},
+		},
+		{
+			name: "nodes",
+			sig:  Signature{FunctionName: "mergeTwoLists", Params: []Param{{"l1", TypeListNode}, {"l2", TypeListNode}}, ReturnType: TypeListNode},
+		},
+		{
+			name: "in place",
+			sig:  Signature{FunctionName: "moveZeroes", Params: []Param{{"nums", "integer[]"}}, ReturnType: TypeVoid, OutputParam: "nums"},
+		},
+		{
+			name:    "invalid function name",
+			sig:     Signature{FunctionName: "two-sum", ReturnType: TypeInteger},
+			wantErr: true,
+		},
+		{
+			name:    "invalid parameter name",
+			sig:     Signature{FunctionName: "f", Params: []Param{{"1x", TypeInteger}}, ReturnType: TypeInteger},
+			wantErr: true,
+		},
+		{
+			name:    "void parameter",
+			sig:     Signature{FunctionName: "f", Params: []Param{{"x", TypeVoid}}, ReturnType: TypeInteger},
+			wantErr: true,
+		},
+		{
+			name:    "unknown parameter type",
+			sig:     Signature{FunctionName: "f", Params: []Param{{"x", "int"}}, ReturnType: TypeInteger},
+			wantErr: true,
+		},
+		{
+			name:    "unknown return type",
+			sig:     Signature{FunctionName: "f", ReturnType: "map"},
+			wantErr: true,
+		},
+		{
+			name:    "void array return type",
+			sig:     Signature{FunctionName: "f", ReturnType: "void[]"},
+			wantErr: true,
+		},
+		{
+			name:    "output parameter missing",
+			sig:     Signature{FunctionName: "f", Params: []Param{{"nums", "integer[]"}}, ReturnType: TypeVoid, OutputParam: "other"},
+			wantErr: true,
+		},
+		{
+			name:    "output parameter with return value",
+			sig:     Signature{FunctionName: "f", Params: []Param{{"nums", "integer[]"}}, ReturnType: TypeInteger, OutputParam: "nums"},
+			wantErr: true,
+		},
+		{
+			name:    "output parameter passed by value",
+			sig:     Signature{FunctionName: "f", Params: []Param{{"n", TypeInteger}}, ReturnType: TypeVoid, OutputParam: "n"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sig.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSignatureValidateInput(t *testing.T) {
+	sig := Signature{
+		FunctionName: "f",
+		Params: []Param{
+			{"nums", "integer[]"},
+			{"k", TypeLong},
+			{"x", TypeDouble},
+			{"ok", TypeBoolean},
+			{"s", TypeString},
+			{"c", TypeCharacter},
+			{"head", TypeListNode},
+			{"root", TypeTreeNode},
+		},
+		ReturnType: TypeVoid,
+	}
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"valid", `[[1,2,3], 9007199254740993, 1.5, true, "abc", "é", [1,2], [1,null,2]]`, false},
+		{"empty containers", `[[], 0, 0, false, "", "a", [], []]`, false},
+		{"not an array", `{"nums": []}`, true},
+		{"trailing data", `[[], 0, 0, false, "", "a", [], []] []`, true},
+		{"too few arguments", `[[1,2,3]]`, true},
+		{"integer overflow", `[[2147483648], 0, 0, false, "", "a", [], []]`, true},
+		{"fractional integer", `[[1.5], 0, 0, false, "", "a", [], []]`, true},
+		{"long overflow", `[[], 9223372036854775808, 0, false, "", "a", [], []]`, true},
+		{"number as boolean", `[[], 0, 0, 1, "", "a", [], []]`, true},
+		{"long character", `[[], 0, 0, false, "", "ab", [], []]`, true},
+		{"null in list", `[[], 0, 0, false, "", "a", [1,null], []]`, true},
+		{"string in tree", `[[], 0, 0, false, "", "a", [], [1,"2"]]`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := sig.ValidateInput(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateInput(%s) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -9,14 +9,52 @@ import (
 type Status string
 
 const (
-	StatusPending  Status = "Pending"
-	StatusRunning  Status = "Running"
-	StatusAccepted Status = "Accepted"
-	StatusWrong    Status = "Wrong Answer"
-	StatusError    Status = "Runtime Error"
-	StatusTimeout  Status = "Time Limit Exceeded"
+	StatusPending   Status = "Pending"
+	StatusRunning   Status = "Running"
+	StatusAccepted  Status = "Accepted"
+	StatusWrong     Status = "Wrong Answer"
+	StatusError     Status = "Runtime Error"
+	StatusTimeout   Status = "Time Limit Exceeded"
+	StatusMemory    Status = "Memory Limit Exceeded"
+	StatusOutput    Status = "Output Limit Exceeded"
+	StatusCompile   Status = "Compile Error"
+	StatusSkipped   Status = "Skipped"        // not run because an earlier test ended the submission
+	StatusInternal  Status = "Internal Error" // the judge itself failed
+	StatusCancelled Status = "Cancelled"      // judging stopped because the client left or the server shut down
 )
 
+// Finished reports whether judging is over, so the status is a verdict
+func (s Status) Finished() bool {
+	return s != StatusPending && s != StatusRunning
+}
+
+// EndsSubmission reports whether a test verdict stops the remaining tests.
+// Wrong answers are cheap to produce and do not; crashes and exceeded limits do.
+func (s Status) EndsSubmission() bool {
+	switch s {
+	case StatusCompile, StatusError, StatusTimeout, StatusMemory, StatusOutput:
+		return true
+	default:
+		return false
+	}
+}
+
+// statusPrecedence ranks failing verdicts. When tests fail in different
+// ways, the submission reports the first verdict in this list that any
+// test has: a failure of the judge itself, a cancelled run or a failed
+// build hides everything else, then crashes, then resource limits, and
+// wrong answers are reported only when every failing test ran to completion.
+var statusPrecedence = []Status{
+	StatusInternal,
+	StatusCancelled,
+	StatusCompile,
+	StatusError,
+	StatusTimeout,
+	StatusMemory,
+	StatusOutput,
+	StatusWrong,
+}
+
 // Submission represents a code submission entity
 type Submission struct {
 	ID        string
@@ -27,7 +65,9 @@ type Submission struct {
 	Runtime   int // in milliseconds
 	Memory    int // in KB
 	Output    string
+	Error     string // why the judge failed, for Internal Error
 	Results   []TestResult
+	Attempts  int // times a judge has claimed the submission
 	CreatedAt time.Time
 }
 
@@ -37,9 +77,20 @@ type TestResult struct {
 	Expected string
 	Actual   string
 	Passed   bool
-	Runtime  int // in milliseconds
+	Status   Status // Accepted, Wrong Answer or the reason the run failed
+	Error    string // compiler output, stderr or another diagnostic when the run failed
+	Signal   string // signal that killed the program, such as SIGSEGV
+	ExitCode int    // non-zero exit code of a program that failed on its own
+	Stdout   string // what the user's code printed, truncated
+	Stderr   string // what the user's code wrote to stderr, truncated
+	Runtime  int    // in milliseconds
+	Memory   int    // peak resident memory in KB
 }
 
+// ResultFunc receives the result of the test at index as soon as it is
+// judged. Tests may finish in any order and on different goroutines.
+type ResultFunc func(index int, result TestResult)
+
 // NewSubmission creates a new Submission entity
 func NewSubmission(id string, problemID uint, language, code string) *Submission {
 	return &Submission{
@@ -57,13 +108,34 @@ func (s *Submission) SetResults(results []TestResult) {
 	s.Results = results
 	s.Status = s.calculateStatus()
 	s.Runtime = s.calculateTotalRuntime()
+	s.Memory = s.calculatePeakMemory()
+	s.Output = s.reportedOutput()
+}
+
+// Fail records that the submission could not be judged
+func (s *Submission) Fail(reason string) {
+	s.Status = StatusInternal
+	s.Error = reason
 }
 
-// calculateStatus determines the overall status from results
+// calculateStatus determines the overall status from results using statusPrecedence
 func (s *Submission) calculateStatus() Status {
+	failed := make(map[Status]bool)
 	for _, r := range s.Results {
-		if !r.Passed {
-			return StatusWrong
+		if r.Passed {
+			continue
+		}
+		// Results stored before per-test verdicts existed only record pass/fail
+		if r.Status == "" {
+			failed[StatusWrong] = true
+		} else {
+			failed[r.Status] = true
+		}
+	}
+
+	for _, status := range statusPrecedence {
+		if failed[status] {
+			return status
 		}
 	}
 	return StatusAccepted
@@ -78,6 +150,31 @@ func (s *Submission) calculateTotalRuntime() int {
 	return total
 }
 
+// calculatePeakMemory returns the highest memory use of any test
+func (s *Submission) calculatePeakMemory() int {
+	peak := 0
+	for _, r := range s.Results {
+		if r.Memory > peak {
+			peak = r.Memory
+		}
+	}
+	return peak
+}
+
+// reportedOutput returns the user's stdout from the test the status refers
+// to: the first failing test, or the first test when all of them passed
+func (s *Submission) reportedOutput() string {
+	for _, r := range s.Results {
+		if !r.Passed {
+			return r.Stdout
+		}
+	}
+	if len(s.Results) > 0 {
+		return s.Results[0].Stdout
+	}
+	return ""
+}
+
 // PassedCount returns the number of passed tests
 func (s *Submission) PassedCount() int {
 	count := 0
@@ -0,0 +1,12 @@
+// Package submission contains the Language value type.
+package submission
+
+// Language describes a programming language submissions can be written in
+type Language struct {
+	ID             string  // identifier used in submissions, such as "python"
+	Name           string  // display name, such as "Python 3"
+	Extension      string  // source file extension, such as ".py"
+	Version        string  // toolchain version reported by the server
+	Available      bool    // whether the toolchain is installed on the server
+	TimeMultiplier float64 // scale of the time limit for this language; 0 means 1
+}
@@ -0,0 +1,34 @@
+// Package submission contains the judging queue interface.
+package submission
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrLeaseLost reports that a judge no longer holds the lease on a
+// submission, because the lease expired and the submission was requeued
+var ErrLeaseLost = errors.New("submission lease lost")
+
+// Queue holds the submissions waiting to be judged. It is durable: a
+// submission saved as Pending stays queued until a judge claims it.
+// Claims are leases that the judge renews while it works; a submission
+// whose lease expires, because its judge died, goes back to Pending until
+// it has been claimed too many times, which fails it.
+type Queue interface {
+	// Claim moves the oldest pending submission to Running, leased to owner
+	// for the given duration, or returns nil when no submission is pending
+	Claim(ctx context.Context, owner string, lease time.Duration) (*Submission, error)
+
+	// Renew extends the lease owner holds on a running submission
+	Renew(ctx context.Context, id, owner string, lease time.Duration) error
+
+	// Complete stores the verdict of a submission and releases its lease.
+	// It fails with ErrLeaseLost when owner no longer holds the lease.
+	Complete(ctx context.Context, submission *Submission, owner string) error
+
+	// RequeueExpired returns running submissions whose lease expired to
+	// Pending, and fails those already claimed maxAttempts times
+	RequeueExpired(ctx context.Context, maxAttempts int) (requeued, failed int64, err error)
+}
@@ -0,0 +1,282 @@
+// Package executor provides the output checkers that judge program results.
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	problemDomain "leetcode-api/internal/domain/problem"
+	submissionDomain "leetcode-api/internal/domain/submission"
+)
+
+// outputChecker decides whether a program's output passes a test case,
+// returning the value to report as the actual result. An error means the
+// output could not be judged.
+type outputChecker func(ctx context.Context, tc problemDomain.TestCase, output string) (actual string, passed bool, err error)
+
+// checkerFor returns the output checker used for a problem, together with a
+// function that releases the resources it holds
+func (e *CodeExecutor) checkerFor(ctx context.Context, problem *problemDomain.Problem) (outputChecker, func(), error) {
+	if problem.Interactive == nil && problem.Checker != nil && problem.Checker.Kind == problemDomain.CheckerProgram {
+		if err := problem.Checker.Validate(); err != nil {
+			return nil, nil, err
+		}
+		prog, err := e.checkerProgram(ctx, *problem.Checker)
+		if err != nil {
+			return nil, nil, err
+		}
+		return e.programChecker(prog), prog.cleanup, nil
+	}
+	check, err := builtinChecker(problem)
+	return check, func() {}, err
+}
+
+// builtinChecker returns the comparison a problem's outputs are judged with
+func builtinChecker(problem *problemDomain.Problem) (outputChecker, error) {
+	if problem.Interactive != nil {
+		return interactiveChecker, nil
+	}
+	if problem.Concurrency != nil {
+		return concurrencyChecker(*problem.Concurrency), nil
+	}
+
+	spec := problem.Checker
+	if spec == nil {
+		// Full programs format their own output, as contest judges allow
+		if problem.IOMode {
+			return tokensChecker, nil
+		}
+		// Drivers encode results as JSON, so formatting differences do not matter
+		if problem.Signature != nil || problem.Design != nil {
+			return jsonChecker(jsonEqual), nil
+		}
+		return exactChecker, nil
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch spec.Kind {
+	case problemDomain.CheckerTokens:
+		return tokensChecker, nil
+	case problemDomain.CheckerJSON:
+		return jsonChecker(jsonEqual), nil
+	case problemDomain.CheckerUnorderedList:
+		return jsonChecker(unorderedEqual(false)), nil
+	case problemDomain.CheckerUnorderedLists:
+		return jsonChecker(unorderedEqual(true)), nil
+	case problemDomain.CheckerFloat:
+		tolerance := spec.Tolerance
+		if tolerance == 0 {
+			tolerance = problemDomain.DefaultFloatTolerance
+		}
+		return jsonChecker(floatEqual(tolerance)), nil
+	default:
+		return exactChecker, nil
+	}
+}
+
+// exactChecker compares output and expected value after trimming whitespace
+func exactChecker(ctx context.Context, tc problemDomain.TestCase, output string) (string, bool, error) {
+	actual := strings.TrimSpace(output)
+	return actual, actual == strings.TrimSpace(tc.Expected), nil
+}
+
+// tokensChecker compares the whitespace-separated tokens of output and
+// expected value, ignoring how lines are spaced and broken
+func tokensChecker(ctx context.Context, tc problemDomain.TestCase, output string) (string, bool, error) {
+	actual := strings.TrimSpace(output)
+	return actual, reflect.DeepEqual(strings.Fields(actual), strings.Fields(tc.Expected)), nil
+}
+
+// jsonChecker decodes output and expected value as JSON and compares them with equal.
+// Output that is not JSON is a wrong answer.
+func jsonChecker(equal func(actual, expected interface{}) bool) outputChecker {
+	return func(ctx context.Context, tc problemDomain.TestCase, output string) (string, bool, error) {
+		actual := strings.TrimSpace(output)
+		expected, err := decodeJSON(tc.Expected)
+		if err != nil {
+			return actual, false, fmt.Errorf("invalid expected output: %w", err)
+		}
+		got, err := decodeJSON(actual)
+		if err != nil {
+			return actual, false, nil
+		}
+		return actual, equal(got, expected), nil
+	}
+}
+
+// decodeJSON decodes a single JSON value, keeping numbers exact
+func decodeJSON(s string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(s))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+	return canonicalNumbers(v), nil
+}
+
+// canonicalNumbers rewrites numbers so that equal values have equal
+// representations, such as 2, 2.0 and 2e0
+func canonicalNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			return json.Number(strconv.FormatInt(i, 10))
+		}
+		if f, err := v.Float64(); err == nil {
+			if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+				return json.Number(strconv.FormatInt(int64(f), 10))
+			}
+			return json.Number(strconv.FormatFloat(f, 'g', -1, 64))
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = canonicalNumbers(v[i])
+		}
+		return v
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = canonicalNumbers(v[k])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// jsonEqual compares two decoded JSON values exactly
+func jsonEqual(actual, expected interface{}) bool {
+	return reflect.DeepEqual(actual, expected)
+}
+
+// unorderedEqual compares two JSON arrays ignoring the order of their
+// elements, and with nested also the order inside element arrays
+func unorderedEqual(nested bool) func(actual, expected interface{}) bool {
+	return func(actual, expected interface{}) bool {
+		a, ok1 := actual.([]interface{})
+		b, ok2 := expected.([]interface{})
+		if !ok1 || !ok2 || len(a) != len(b) {
+			return false
+		}
+		return reflect.DeepEqual(sortedElements(a, nested), sortedElements(b, nested))
+	}
+}
+
+// sortedElements returns the canonical encodings of an array's elements in sorted order
+func sortedElements(values []interface{}, nested bool) []string {
+	keys := make([]string, len(values))
+	for i, v := range values {
+		if inner, ok := v.([]interface{}); ok && nested {
+			// Keep the brackets so an inner list never matches a scalar
+			keys[i] = "[" + strings.Join(sortedElements(inner, false), ",") + "]"
+			continue
+		}
+		encoded, _ := json.Marshal(v)
+		keys[i] = string(encoded)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// floatEqual compares two JSON values structurally, accepting numbers whose
+// absolute or relative difference is within tolerance
+func floatEqual(tolerance float64) func(actual, expected interface{}) bool {
+	var equal func(a, b interface{}) bool
+	equal = func(a, b interface{}) bool {
+		switch b := b.(type) {
+		case json.Number:
+			an, ok := a.(json.Number)
+			if !ok {
+				return false
+			}
+			x, err1 := an.Float64()
+			y, err2 := b.Float64()
+			if err1 != nil || err2 != nil {
+				return false
+			}
+			diff := math.Abs(x - y)
+			return diff <= tolerance || diff <= tolerance*math.Max(math.Abs(x), math.Abs(y))
+		case []interface{}:
+			as, ok := a.([]interface{})
+			if !ok || len(as) != len(b) {
+				return false
+			}
+			for i := range b {
+				if !equal(as[i], b[i]) {
+					return false
+				}
+			}
+			return true
+		default:
+			return reflect.DeepEqual(a, b)
+		}
+	}
+	return equal
+}
+
+// checkerProgram builds the problem author's checker program, which reads
+// its payload from stdin
+func (e *CodeExecutor) checkerProgram(ctx context.Context, spec problemDomain.CheckerSpec) (*program, error) {
+	prog, err := e.authorProgram(ctx, "checker", spec.Language, spec.Code)
+	if err != nil {
+		return nil, err
+	}
+	prog.stdoutResult = true
+	return prog, nil
+}
+
+// authorProgram builds a program written by the problem author, such as a
+// checker, which runs as-is with its language's runner
+func (e *CodeExecutor) authorProgram(ctx context.Context, role, language, code string) (*program, error) {
+	runner, ok := e.languages.Runner(language)
+	if !ok || runner.Driver() == sqlDriver || !e.languages.Available(language) {
+		return nil, fmt.Errorf("%s programs cannot be written in %s", role, language)
+	}
+	tmpl := driverTemplates[runner.Driver()]
+	harness, solution := tmpl.standalone(code)
+	prog, err := e.assemble(ctx, runner, tmpl.sources(runner, harness, solution), tmpl.prepare)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", role, err)
+	}
+	return prog, nil
+}
+
+// programChecker runs the problem author's checker program on every output
+func (e *CodeExecutor) programChecker(prog *program) outputChecker {
+	return func(ctx context.Context, tc problemDomain.TestCase, output string) (string, bool, error) {
+		actual := strings.TrimSpace(output)
+
+		var payload bytes.Buffer
+		json.NewEncoder(&payload).Encode(map[string]string{
+			"input":    tc.Input,
+			"expected": strings.TrimSpace(tc.Expected),
+			"actual":   actual,
+		})
+		run, err := e.runCode(ctx, prog, problemDomain.TestCase{Input: payload.String(), Files: tc.Files})
+		if err != nil {
+			return actual, false, fmt.Errorf("checker: %w", err)
+		}
+
+		switch {
+		case run.status == "":
+			return actual, true, nil
+		case run.status == submissionDomain.StatusError && run.exitCode == 1:
+			return actual, false, nil
+		default:
+			return actual, false, fmt.Errorf("checker failed: %s %s", run.status, run.message)
+		}
+	}
+}
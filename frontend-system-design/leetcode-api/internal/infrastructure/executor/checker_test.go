@@ -0,0 +1,140 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	problemDomain "leetcode-api/internal/domain/problem"
+)
+
+func TestCanonicalNumbers(t *testing.T) {
+	tests := []struct {
+		input string
+		want  interface{}
+	}{
+		{`2`, json.Number("2")},
+		{`2.0`, json.Number("2")},
+		{`2e0`, json.Number("2")},
+		{`-0.0`, json.Number("0")},
+		{`1.50`, json.Number("1.5")},
+		{`9007199254740993`, json.Number("9007199254740993")},
+		{`1e20`, json.Number("1e+20")},
+		{`[1.0, [2e1, "3.0"]]`, []interface{}{json.Number("1"), []interface{}{json.Number("20"), "3.0"}}},
+		{`{"a": 1.0}`, map[string]interface{}{"a": json.Number("1")}},
+		{`null`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := decodeJSON(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeJSON(%s) = %#v, want %#v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnorderedEqual(t *testing.T) {
+	tests := []struct {
+		name             string
+		actual, expected string
+		nested           bool
+		want             bool
+	}{
+		{"same order", `[1,2,3]`, `[1,2,3]`, false, true},
+		{"reordered", `[3,1,2]`, `[1,2,3]`, false, true},
+		{"duplicates counted", `[1,1,2]`, `[1,2,2]`, false, false},
+		{"different length", `[1,2]`, `[1,2,3]`, false, false},
+		{"not an array", `1`, `[1]`, false, false},
+		{"numbers canonical", `[2.0,1]`, `[1,2]`, false, true},
+		{"inner order kept", `[[2,1],[3]]`, `[[3],[1,2]]`, false, false},
+		{"outer reordered", `[[3],[1,2]]`, `[[1,2],[3]]`, false, true},
+		{"nested reordered", `[[2,1],[3]]`, `[[3],[1,2]]`, true, true},
+		{"nested different", `[[2,1],[3]]`, `[[3],[1,3]]`, true, false},
+		{"nested list is not a scalar", `["a"]`, `[["a"]]`, true, false},
+		{"nested lists are not their joined elements", `["ab","c"]`, `[["ab"],["c"]]`, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unorderedEqual(tt.nested)(mustDecode(t, tt.actual), mustDecode(t, tt.expected)); got != tt.want {
+				t.Errorf("unorderedEqual(%v)(%s, %s) = %v, want %v", tt.nested, tt.actual, tt.expected, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFloatEqual(t *testing.T) {
+	tests := []struct {
+		name             string
+		actual, expected string
+		want             bool
+	}{
+		{"exact", `2.5`, `2.5`, true},
+		{"within absolute tolerance", `0.333333`, `0.3333333`, true},
+		{"within relative tolerance", `1000000.5`, `1000000`, true},
+		{"outside tolerance", `0.34`, `0.33`, false},
+		{"arrays", `[1.000001, 2]`, `[1, 2.000001]`, true},
+		{"array length", `[1]`, `[1, 2]`, false},
+		{"nested", `[[0.5], [0.25]]`, `[[0.5000001], [0.25]]`, true},
+		{"number against string", `"1"`, `1`, false},
+		{"other values exact", `[true, "a"]`, `[true, "a"]`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal := floatEqual(problemDomain.DefaultFloatTolerance)
+			if got := equal(mustDecode(t, tt.actual), mustDecode(t, tt.expected)); got != tt.want {
+				t.Errorf("floatEqual(%s, %s) = %v, want %v", tt.actual, tt.expected, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgramChecker(t *testing.T) {
+	e := newShellExecutor(t, 1)
+	tests := []struct {
+		name       string
+		code       string
+		wantPassed bool
+		wantErr    bool
+	}{
+		{"exit 0 accepts", `exit 0`, true, false},
+		{"exit 1 rejects", `exit 1`, false, false},
+		{"other exit fails", `exit 2`, false, true},
+		{"crash fails", `kill -9 $$`, false, true},
+		{"payload accepted", `grep -q '"actual":"42","expected":"41","input":"\[1\]"'`, true, false},
+		{"payload rejected", `grep -q '"actual":"41"'`, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			prog, err := e.checkerProgram(ctx, problemDomain.CheckerSpec{Kind: problemDomain.CheckerProgram, Language: "sh", Code: tt.code})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer prog.cleanup()
+
+			tc := problemDomain.TestCase{Input: `[1]`, Expected: `41`}
+			actual, passed, err := e.programChecker(prog)(ctx, tc, " 42\n")
+			if actual != "42" {
+				t.Errorf("actual = %q, want %q", actual, "42")
+			}
+			if passed != tt.wantPassed || (err != nil) != tt.wantErr {
+				t.Errorf("checker = %v, %v; want passed %v, error %v", passed, err, tt.wantPassed, tt.wantErr)
+			}
+		})
+	}
+}
+
+// mustDecode decodes a JSON value as the checkers do
+func mustDecode(t *testing.T, s string) interface{} {
+	t.Helper()
+	v, err := decodeJSON(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
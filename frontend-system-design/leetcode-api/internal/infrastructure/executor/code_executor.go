@@ -4,151 +4,516 @@ package executor
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	problemDomain "leetcode-api/internal/domain/problem"
 	submissionDomain "leetcode-api/internal/domain/submission"
+	"leetcode-api/internal/infrastructure/sandbox"
+)
+
+const (
+	// compileTimeout bounds the one-off compile step of compiled languages
+	compileTimeout = 30 * time.Second
+	// compileMemoryLimit is the resident memory in KB a compile may use
+	compileMemoryLimit = 2 << 20
+	// maxOutputSize is how much a program may print before Output Limit Exceeded
+	maxOutputSize = 1 << 20
+	// maxStderrSize is how much diagnostic output is kept from a run
+	maxStderrSize = 64 << 10
+)
+
+// Config holds the executor's tunable limits. Zero values select the defaults.
+type Config struct {
+	Timeout     time.Duration // wall-clock limit per test case
+	StdoutLimit int           // bytes of the user's own stdout and stderr kept per test case
+	Workers     int           // test cases run at once across all submissions; defaults to the CPU count
+	Languages   *Registry     // language runners; defaults to the built-in definitions
+}
+
+// compilePolicy confines compilers, which fork helpers and write build
+// artifacts but need no more than the compile timeout of CPU
+var compilePolicy = sandbox.Policy{
+	CPUTime:   compileTimeout,
+	FileSize:  256 << 20,
+	Processes: 256,
+	OpenFiles: 1024,
+}
+
+// Default limits used for zero Config values
+const (
+	defaultTimeout     = 5 * time.Second
+	defaultStdoutLimit = 8 << 10
 )
 
 // CodeExecutor runs code against test cases
 type CodeExecutor struct {
-	timeout time.Duration
+	timeout     time.Duration
+	stdoutLimit int
+	workers     chan struct{} // worker pool slots shared by all submissions
+	languages   *Registry
+	sandbox     *sandbox.Sandbox // nil compiles and runs submissions unconfined
+
+	cacheMu sync.Mutex
+	caches  map[string]string // build cache directory by runner name
+}
+
+// program is a submission prepared once and run for each test case.
+// Generated drivers read the test input from stdin and write the result
+// to file descriptor 3, leaving stdout to the user's own prints.
+type program struct {
+	command      func(ctx context.Context, input string) *exec.Cmd
+	policy       sandbox.Policy // sandbox resources the language runtime needs
+	timeout      time.Duration  // wall-clock limit per run
+	memoryLimit  int            // resident memory in KB per run, killed beyond it; 0 is unwatched
+	dir          string         // scratch directory holding build artifacts, if any
+	stdoutResult bool           // the program's stdout is its result, as for shell scripts
+	judge        *program       // judge program an interactive solution talks to, if any
+	queryLimit   int            // queries the judge allows per test case
 }
 
-// New creates a new CodeExecutor
-func New(timeout time.Duration) *CodeExecutor {
-	if timeout == 0 {
-		timeout = 5 * time.Second
+// newProgramDir creates a scratch directory for build artifacts that
+// sandboxed commands, which may run as another user, can read
+func newProgramDir(prefix string) (string, error) {
+	dir, err := os.MkdirTemp("", prefix)
+	if err != nil {
+		return "", err
 	}
-	return &CodeExecutor{timeout: timeout}
+	if err := os.Chmod(dir, 0o755); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return dir, nil
 }
 
-// Execute runs code against test cases
-func (e *CodeExecutor) Execute(language, code string, testCases []problemDomain.TestCase) []submissionDomain.TestResult {
+// cleanup removes the program's build artifacts and those of its judge
+func (p *program) cleanup() {
+	if p.dir != "" {
+		os.RemoveAll(p.dir)
+	}
+	if p.judge != nil {
+		p.judge.cleanup()
+	}
+}
+
+// New creates a new CodeExecutor. A nil sandbox compiles and runs submissions unconfined.
+func New(cfg Config, sb *sandbox.Sandbox) *CodeExecutor {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.StdoutLimit <= 0 {
+		cfg.StdoutLimit = defaultStdoutLimit
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = runtime.NumCPU()
+	}
+	if cfg.Languages == nil {
+		registry, err := DefaultRegistry()
+		if err != nil {
+			// The built-in definitions are embedded at build time
+			panic(err)
+		}
+		cfg.Languages = registry
+	}
+	return &CodeExecutor{
+		timeout:     cfg.Timeout,
+		stdoutLimit: cfg.StdoutLimit,
+		workers:     make(chan struct{}, cfg.Workers),
+		languages:   cfg.Languages,
+		sandbox:     sb,
+	}
+}
+
+// Execute runs code against test cases, calling the entry point declared by
+// the problem's signature. Test cases run concurrently on the executor's
+// worker pool; once a test ends with a verdict that ends the submission, the
+// tests after it are cancelled and reported as skipped. Cancelling ctx kills
+// the running programs and reports the unfinished tests as cancelled.
+// onResult, when not nil, receives each test's result as soon as it is known.
+func (e *CodeExecutor) Execute(ctx context.Context, problem *problemDomain.Problem, language, code string, testCases []problemDomain.TestCase, onResult submissionDomain.ResultFunc) []submissionDomain.TestResult {
 	results := make([]submissionDomain.TestResult, len(testCases))
+	report := func(i int, result submissionDomain.TestResult) {
+		results[i] = result
+		if onResult != nil {
+			onResult(i, result)
+		}
+	}
 
-	for i, tc := range testCases {
-		start := time.Now()
-		output, err := e.runCode(language, code, tc.Input)
-		runtime := int(time.Since(start).Milliseconds())
-
-		actual := strings.TrimSpace(output)
-		expected := strings.TrimSpace(tc.Expected)
-
-		results[i] = submissionDomain.TestResult{
-			Input:    tc.Input,
-			Expected: expected,
-			Actual:   actual,
-			Passed:   err == nil && actual == expected,
-			Runtime:  runtime,
+	if runner, ok := e.languages.Runner(language); ok && runner.Driver() == sqlDriver {
+		for i, result := range e.executeSQL(ctx, problem, code, testCases) {
+			report(i, result)
 		}
+		return results
+	}
 
-		if err != nil {
-			results[i].Actual = err.Error()
-			results[i].Passed = false
+	prog, prepErr := e.prepare(ctx, problem, language, code)
+	if prog != nil {
+		defer prog.cleanup()
+	}
+	check, release, checkErr := e.checkerFor(ctx, problem)
+	if release != nil {
+		defer release()
+	}
+	if prepErr == nil {
+		prepErr = checkErr
+	}
+	if prepErr != nil {
+		for i, tc := range testCases {
+			result := newTestResult(tc)
+			result.Status = preparationStatus(prepErr)
+			result.Error = prepErr.Error()
+			if ctx.Err() != nil {
+				result = cancelledResult(tc)
+			}
+			report(i, result)
+		}
+		return results
+	}
+
+	contexts := make([]context.Context, len(testCases))
+	cancels := make([]context.CancelFunc, len(testCases))
+	for i := range testCases {
+		contexts[i], cancels[i] = context.WithCancel(ctx)
+		defer cancels[i]()
+	}
+	var mu sync.Mutex
+	cancelledFrom := len(testCases)
+	// endAfter cancels every test after the failed one, keeping earlier
+	// tests running so the reported failure is the first one in order
+	endAfter := func(failed int) {
+		mu.Lock()
+		defer mu.Unlock()
+		for ; cancelledFrom > failed+1; cancelledFrom-- {
+			cancels[cancelledFrom-1]()
 		}
 	}
 
+	// Tests take worker slots in order, so cancelled ones mostly never start
+	var wg sync.WaitGroup
+	// stopped reports a test whose context ended before it finished
+	stopped := func(tc problemDomain.TestCase) submissionDomain.TestResult {
+		if ctx.Err() != nil {
+			return cancelledResult(tc)
+		}
+		result := newTestResult(tc)
+		result.Status = submissionDomain.StatusSkipped
+		return result
+	}
+	for i, tc := range testCases {
+		testCtx := contexts[i]
+		select {
+		case e.workers <- struct{}{}:
+		case <-testCtx.Done():
+			report(i, stopped(tc))
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, tc problemDomain.TestCase) {
+			defer wg.Done()
+			defer func() { <-e.workers }()
+
+			result := e.runTest(testCtx, prog, check, tc)
+			if testCtx.Err() != nil {
+				result = stopped(tc)
+			} else if result.Status.EndsSubmission() {
+				endAfter(i)
+			}
+			report(i, result)
+		}(i, tc)
+	}
+	wg.Wait()
+
 	return results
 }
 
-// runCode executes code in a subprocess
-func (e *CodeExecutor) runCode(language, code, input string) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
-	defer cancel()
+// newTestResult returns the result for a test case that has not been judged yet
+func newTestResult(tc problemDomain.TestCase) submissionDomain.TestResult {
+	return submissionDomain.TestResult{
+		Input:    tc.DisplayInput(),
+		Expected: strings.TrimSpace(tc.Expected),
+	}
+}
 
-	var cmd *exec.Cmd
+// cancelledResult returns the result for a test cut short by cancellation
+func cancelledResult(tc problemDomain.TestCase) submissionDomain.TestResult {
+	result := newTestResult(tc)
+	result.Status = submissionDomain.StatusCancelled
+	result.Error = "judging was cancelled"
+	return result
+}
 
-	switch language {
-	case "javascript":
-		wrappedCode := e.wrapJavaScript(code, input)
-		cmd = exec.CommandContext(ctx, "node", "-e", wrappedCode)
+// runTest runs a single test case and judges its outcome
+func (e *CodeExecutor) runTest(ctx context.Context, prog *program, check outputChecker, tc problemDomain.TestCase) submissionDomain.TestResult {
+	result := newTestResult(tc)
 
-	case "python":
-		wrappedCode := e.wrapPython(code, input)
-		cmd = exec.CommandContext(ctx, "python3", "-c", wrappedCode)
+	start := time.Now()
+	var run *runOutcome
+	var err error
+	if prog.judge != nil {
+		run, err = e.runInteractive(ctx, prog, tc)
+	} else {
+		run, err = e.runCode(ctx, prog, tc)
+	}
+	result.Runtime = int(time.Since(start).Milliseconds())
+	if err != nil {
+		result.Status = submissionDomain.StatusInternal
+		result.Error = err.Error()
+		return result
+	}
 
-	case "go":
-		wrappedCode := e.wrapGo(code, input)
-		cmd = exec.CommandContext(ctx, "go", "run", "-")
-		cmd.Stdin = strings.NewReader(wrappedCode)
+	result.Memory = run.memory
+	result.Signal = run.signal
+	result.ExitCode = run.exitCode
+	result.Stdout = truncateOutput(run.stdout, e.stdoutLimit)
+	result.Stderr = truncateOutput(run.stderr, e.stdoutLimit)
 
+	switch {
+	// Exceeding the limit fails the test even if the run finished,
+	// and explains crashes caused by allocation failures
+	case prog.memoryLimit > 0 && run.memory > prog.memoryLimit:
+		result.Status = submissionDomain.StatusMemory
+	case run.status != "":
+		result.Status = run.status
+		result.Error = run.message
 	default:
-		return "", fmt.Errorf("unsupported language: %s", language)
+		result.Actual, result.Passed, err = check(ctx, tc, run.result)
+		switch {
+		case err != nil:
+			result.Status = submissionDomain.StatusInternal
+			result.Error = err.Error()
+		case result.Passed:
+			result.Status = submissionDomain.StatusAccepted
+		default:
+			result.Status = submissionDomain.StatusWrong
+		}
 	}
+	return result
+}
 
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+// preparationStatus returns the verdict for tests of a submission that could
+// not be prepared. Only compile errors are the submission's fault; anything
+// else, such as a problem without a signature, is the judge's.
+func preparationStatus(err error) submissionDomain.Status {
+	var compileErr *CompileError
+	if errors.As(err, &compileErr) {
+		return submissionDomain.StatusCompile
+	}
+	return submissionDomain.StatusInternal
+}
 
-	err := cmd.Run()
-	if ctx.Err() == context.DeadlineExceeded {
-		return "", fmt.Errorf("Time Limit Exceeded")
+// prepare builds the program for a submission with its language's runner
+func (e *CodeExecutor) prepare(ctx context.Context, problem *problemDomain.Problem, language, code string) (*program, error) {
+	runner, ok := e.languages.Runner(language)
+	if !ok || !e.languages.Available(language) {
+		return nil, fmt.Errorf("unsupported language: %s", language)
 	}
+	prog, err := e.build(ctx, problem, runner, code)
 	if err != nil {
-		if stderr.Len() > 0 {
-			return "", fmt.Errorf("%s", stderr.String())
-		}
-		return "", err
+		return nil, err
 	}
+	return prog, nil
+}
 
-	return stdout.String(), nil
+// runOutcome describes how a single run of a program ended
+type runOutcome struct {
+	result   string // value reported by the driver
+	stdout   string // the user's own prints; empty when stdout is the result
+	stderr   string
+	message  string                  // diagnostic for runtime errors
+	memory   int                     // peak resident memory in KB
+	status   submissionDomain.Status // set when the run failed; the checker judges the rest
+	signal   string                  // signal that killed the program
+	exitCode int
 }
 
-func (e *CodeExecutor) wrapJavaScript(code, input string) string {
-	return fmt.Sprintf(`
-const input = %s;
-%s
-const result = twoSum ? twoSum(...input) : 
-               isPalindrome ? isPalindrome(...input) :
-               maxProfit ? maxProfit(...input) :
-               isValid ? isValid(...input) :
-               reverseList ? reverseList(...input) :
-               null;
-console.log(JSON.stringify(result));
-`, input, code)
+// runCode executes a prepared program in a subprocess, inside a throwaway
+// working directory holding the test case's fixture files. The error is
+// reserved for failures of the judge itself; failures of the program are
+// reported in the outcome's status.
+func (e *CodeExecutor) runCode(ctx context.Context, prog *program, tc problemDomain.TestCase) (*runOutcome, error) {
+	workDir, err := os.MkdirTemp("", "judge-run-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(workDir)
+
+	if err := writeFixtures(workDir, tc.Files); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, prog.timeout)
+	defer cancel()
+
+	cmd := prog.command(ctx, tc.Input)
+	cmd.Dir = workDir
+	// Unconfined children may outlive the killed process and hold its pipes open
+	cmd.WaitDelay = time.Second
+	if e.sandbox != nil {
+		if err := e.sandbox.Wrap(cmd, prog.policy, sandbox.Mount{Path: prog.dir}); err != nil {
+			return nil, err
+		}
+	}
+	killProcessGroup(cmd)
+
+	// Printing past the limit stops the program; cancelling is distinguishable from the deadline
+	stdout := &limitedBuffer{limit: maxOutputSize, onOverflow: cancel}
+	stderr := &limitedBuffer{limit: maxStderrSize}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	result, collect := stdout, func() {}
+	if !prog.stdoutResult {
+		result = &limitedBuffer{limit: maxOutputSize, onOverflow: cancel}
+		if collect, err = collectResult(cmd, result); err != nil {
+			return nil, err
+		}
+	}
+
+	// Going over the memory limit stops the program like printing too much
+	var watched int
+	var exceeded bool
+	if err = cmd.Start(); err == nil {
+		watch := watchMemory(cmd.Process.Pid, prog.memoryLimit, cancel)
+		err = cmd.Wait()
+		watched, exceeded = watch.stop()
+	}
+	collect()
+
+	run := &runOutcome{
+		result:   result.String(),
+		stderr:   stderr.String(),
+		memory:   max(peakMemory(cmd.ProcessState), watched),
+		signal:   exitSignal(cmd.ProcessState),
+		exitCode: cmd.ProcessState.ExitCode(),
+	}
+	if run.exitCode < 0 {
+		run.exitCode = 0
+	}
+	if !prog.stdoutResult {
+		run.stdout = stdout.String()
+	}
+
+	switch {
+	case e.sandbox != nil && e.sandbox.HelperFailed(cmd.ProcessState, run.stderr):
+		// The program never started, which is no fault of the submission
+		run.status = submissionDomain.StatusInternal
+		run.message = strings.TrimSpace(run.stderr)
+	case exceeded:
+		run.status = submissionDomain.StatusMemory
+	case ctx.Err() == context.DeadlineExceeded || run.signal == "SIGXCPU":
+		run.status = submissionDomain.StatusTimeout
+	case stdout.overflowed || result.overflowed || run.signal == "SIGXFSZ":
+		run.status = submissionDomain.StatusOutput
+	case err != nil:
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, err
+		}
+		run.status = submissionDomain.StatusError
+		run.message = strings.TrimSpace(run.stderr)
+		if run.message == "" {
+			run.message = err.Error()
+		}
+	}
+	return run, nil
 }
 
-func (e *CodeExecutor) wrapPython(code, input string) string {
-	return fmt.Sprintf(`
-import json
-input_data = %s
-%s
+// collectResult connects file descriptor 3 of cmd to a pipe copied into dst.
+// The returned function waits for the copy and must be called once cmd has
+// exited, before dst is read.
+func collectResult(cmd *exec.Cmd, dst io.Writer) (func(), error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	cmd.ExtraFiles = []*os.File{w}
 
-result = None
-if 'twoSum' in dir():
-    result = twoSum(*input_data)
-elif 'isPalindrome' in dir():
-    result = isPalindrome(*input_data)
-elif 'maxProfit' in dir():
-    result = maxProfit(*input_data)
-elif 'isValid' in dir():
-    result = isValid(*input_data)
-elif 'reverseList' in dir():
-    result = reverseList(*input_data)
+	copied := make(chan struct{})
+	go func() {
+		io.Copy(dst, r)
+		close(copied)
+	}()
 
-print(json.dumps(result))
-`, input, code)
+	return func() {
+		// The child holds its own copy of the write end
+		w.Close()
+		// Unconfined descendants may keep the pipe open after the program exits
+		r.SetReadDeadline(time.Now().Add(time.Second))
+		<-copied
+		r.Close()
+	}, nil
 }
 
-func (e *CodeExecutor) wrapGo(code, input string) string {
-	return fmt.Sprintf(`
-package main
+// truncateOutput shortens s to at most limit bytes, marking the cut
+func truncateOutput(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	// Never split a multi-byte character
+	for limit > 0 && !utf8.RuneStart(s[limit]) {
+		limit--
+	}
+	return s[:limit] + "\n... (output truncated)"
+}
 
-import (
-	"encoding/json"
-	"fmt"
-)
+// limitedBuffer keeps the first limit bytes written to it and discards the
+// rest, calling onOverflow the first time output is discarded. It never
+// fails writes, so the program is not blocked on a pipe nobody drains.
+type limitedBuffer struct {
+	buf        bytes.Buffer // not embedded: its ReadFrom would bypass the limit
+	limit      int
+	overflowed bool
+	onOverflow func()
+}
 
-%s
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); len(p) > room {
+		b.buf.Write(p[:room])
+		if !b.overflowed {
+			b.overflowed = true
+			if b.onOverflow != nil {
+				b.onOverflow()
+			}
+		}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
 
-func main() {
-	input := %s
-	_ = input
-	fmt.Println("[]")
+// String returns the kept output
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
 }
-`, code, "`"+input+"`")
+
+// writeFixtures writes a test case's fixture files into dir
+func writeFixtures(dir string, files map[string]string) error {
+	for name, content := range files {
+		if name == "" || name == "." || name == ".." || filepath.Base(name) != name {
+			return fmt.Errorf("invalid fixture file name %q", name)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Supports reports whether submissions in a language can be judged
+func (e *CodeExecutor) Supports(language string) bool {
+	return e.languages.Available(language)
+}
+
+// Languages describes the languages the executor knows about
+func (e *CodeExecutor) Languages() []submissionDomain.Language {
+	return e.languages.Languages()
 }
@@ -0,0 +1,151 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	problemDomain "leetcode-api/internal/domain/problem"
+	submissionDomain "leetcode-api/internal/domain/submission"
+)
+
+// echoScript prints its input after sleeping for a tenth of a second per
+// unit of it, and fails on the input "fail"
+const echoScript = `read n
+[ "$n" = fail ] && exit 3
+sleep "0.$n"
+echo "$n"`
+
+func TestExecuteKeepsTestOrder(t *testing.T) {
+	e := newShellExecutor(t, 4)
+	problem := &problemDomain.Problem{Slug: "echo", IOMode: true}
+	// Later tests finish first
+	testCases := []problemDomain.TestCase{
+		{Input: "4", Expected: "4"},
+		{Input: "3", Expected: "3"},
+		{Input: "2", Expected: "2"},
+		{Input: "0", Expected: "0"},
+	}
+
+	// Results are reported from the tests' goroutines
+	var mu sync.Mutex
+	reported := 0
+	results := e.Execute(context.Background(), problem, "sh", echoScript, testCases, func(i int, result submissionDomain.TestResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		reported++
+	})
+
+	for i, result := range results {
+		if result.Status != submissionDomain.StatusAccepted || result.Actual != testCases[i].Expected {
+			t.Errorf("result %d = %s %q, want Accepted %q", i, result.Status, result.Actual, testCases[i].Expected)
+		}
+	}
+	if reported != len(testCases) {
+		t.Errorf("onResult called %d times, want %d", reported, len(testCases))
+	}
+}
+
+func TestExecuteSkipsTestsAfterFailure(t *testing.T) {
+	tests := []struct {
+		name    string
+		workers int
+		inputs  []string
+		want    []submissionDomain.Status
+	}{
+		{
+			name:    "sequential",
+			workers: 1,
+			inputs:  []string{"0", "fail", "0", "0"},
+			want: []submissionDomain.Status{
+				submissionDomain.StatusAccepted, submissionDomain.StatusError,
+				submissionDomain.StatusSkipped, submissionDomain.StatusSkipped,
+			},
+		},
+		{
+			// The slower test before the failure still runs to its verdict
+			name:    "earlier test still running",
+			workers: 3,
+			inputs:  []string{"3", "fail", "9", "0"},
+			want: []submissionDomain.Status{
+				submissionDomain.StatusAccepted, submissionDomain.StatusError,
+				submissionDomain.StatusSkipped, submissionDomain.StatusSkipped,
+			},
+		},
+		{
+			name:    "first test fails",
+			workers: 2,
+			inputs:  []string{"fail", "9", "9"},
+			want: []submissionDomain.Status{
+				submissionDomain.StatusError, submissionDomain.StatusSkipped, submissionDomain.StatusSkipped,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newShellExecutor(t, tt.workers)
+			problem := &problemDomain.Problem{Slug: "echo", IOMode: true}
+			testCases := make([]problemDomain.TestCase, len(tt.inputs))
+			for i, input := range tt.inputs {
+				testCases[i] = problemDomain.TestCase{Input: input, Expected: input}
+			}
+
+			results := e.Execute(context.Background(), problem, "sh", echoScript, testCases, nil)
+			for i, result := range results {
+				if result.Status != tt.want[i] {
+					t.Errorf("result %d = %s, want %s", i, result.Status, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteCancelled(t *testing.T) {
+	e := newShellExecutor(t, 2)
+	problem := &problemDomain.Problem{Slug: "echo", IOMode: true}
+	testCases := []problemDomain.TestCase{{Input: "9", Expected: "9"}, {Input: "9", Expected: "9"}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i, result := range e.Execute(ctx, problem, "sh", echoScript, testCases, nil) {
+		if result.Status != submissionDomain.StatusCancelled {
+			t.Errorf("result %d = %s, want %s", i, result.Status, submissionDomain.StatusCancelled)
+		}
+	}
+}
+
+func TestPreparationStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want submissionDomain.Status
+	}{
+		{"compile error", &CompileError{Output: "main.cpp:1: error"}, submissionDomain.StatusCompile},
+		{"wrapped compile error", fmt.Errorf("judge: %w", &CompileError{Output: "error"}), submissionDomain.StatusCompile},
+		{"missing signature", errors.New("problem two-sum has no function signature"), submissionDomain.StatusInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := preparationStatus(tt.err); got != tt.want {
+				t.Errorf("preparationStatus(%v) = %s, want %s", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteWithoutSignatureIsInternal(t *testing.T) {
+	e := New(Config{Timeout: 5 * time.Second}, nil)
+	if !e.languages.Available("javascript") {
+		t.Skip("node is not installed")
+	}
+	problem := &problemDomain.Problem{Slug: "two-sum"}
+	testCases := []problemDomain.TestCase{{Input: "[1]", Expected: "1"}}
+
+	result := e.Execute(context.Background(), problem, "javascript", "function f(n) { return n }", testCases, nil)[0]
+	if result.Status != submissionDomain.StatusInternal {
+		t.Errorf("status = %s (%s), want %s", result.Status, result.Error, submissionDomain.StatusInternal)
+	}
+}
@@ -0,0 +1,229 @@
+// Package executor provides the harness for concurrency problems.
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	problemDomain "leetcode-api/internal/domain/problem"
+)
+
+// defaultConcurrencyRuns is used when a spec does not set its own repetition count
+const defaultConcurrencyRuns = 20
+
+// wrapPythonConcurrency generates a harness that starts one thread per call
+// in the test input. Run N shuffles the start order and per-thread start
+// delays with seed N (run 0 keeps the input order), and the harness writes
+// the output of every run to fd 3 as a JSON array of strings. As with
+// wrapPython, the user's code is executed from its own file.
+func wrapPythonConcurrency(code string, spec problemDomain.ConcurrencySpec) (string, string, error) {
+	tokens, _ := json.Marshal(spec.Tokens)
+
+	return fmt.Sprintf(`
+import json
+import os
+import random
+import sys
+import threading
+import time
+
+with open(os.path.join(os.path.dirname(os.path.abspath(__file__)), 'solution.py')) as _judge_source:
+    exec(compile(_judge_source.read(), 'solution.py', 'exec'))
+
+_judge_tokens = json.loads(%s)
+
+
+def _judge_run(calls, seed):
+    rng = random.Random(seed)
+    obj = %s()
+    out = []
+    lock = threading.Lock()
+
+    def emitter(token):
+        def emit():
+            with lock:
+                out.append(token)
+        return emit
+
+    order = list(range(len(calls)))
+    if seed:
+        rng.shuffle(order)
+
+    threads = []
+    for i in order:
+        method = getattr(obj, calls[i])
+        delay = rng.random() / 500
+
+        def target(method=method, token=_judge_tokens[calls[i]], delay=delay):
+            time.sleep(delay)
+            method(emitter(token))
+
+        threads.append(threading.Thread(target=target, daemon=True))
+    for t in threads:
+        t.start()
+    for t in threads:
+        t.join()
+    return ''.join(out)
+
+
+_judge_calls = json.load(sys.stdin)
+for name in _judge_calls:
+    if name not in _judge_tokens:
+        sys.exit('unknown method %%r' %% name)
+with os.fdopen(3, 'w') as _judge_out:
+    _judge_out.write(json.dumps([_judge_run(_judge_calls, seed) for seed in range(%d)]) + '\n')
+`, strconv.Quote(string(tokens)), spec.ClassName, spec.Runs), code, nil
+}
+
+// wrapGoConcurrency generates the Go harness, which runs calls on goroutines
+// the same way. Solutions define a New<ClassName>() constructor and exported
+// methods taking a func() callback.
+func wrapGoConcurrency(code string, spec problemDomain.ConcurrencySpec) (string, string, error) {
+	var cases strings.Builder
+	for _, method := range spec.Methods() {
+		fmt.Fprintf(&cases, "\t\tcase %q:\n\t\t\tcall = func() { obj.%s(emit(%q)) }\n",
+			method, exportedName(method), spec.Tokens[method])
+	}
+
+	return fmt.Sprintf(`package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+func main() {
+	var calls []string
+	if err := json.NewDecoder(os.Stdin).Decode(&calls); err != nil {
+		judgeFail("invalid test input: %%v", err)
+	}
+
+	runs := make([]string, %d)
+	for seed := range runs {
+		runs[seed] = judgeRun(calls, int64(seed))
+	}
+	out, _ := json.Marshal(runs)
+	os.NewFile(3, "result").Write(append(out, '\n'))
+}
+
+func judgeRun(calls []string, seed int64) string {
+	rng := rand.New(rand.NewSource(seed))
+	obj := New%s()
+
+	var mu sync.Mutex
+	var out strings.Builder
+	emit := func(token string) func() {
+		return func() {
+			mu.Lock()
+			out.WriteString(token)
+			mu.Unlock()
+		}
+	}
+
+	order := make([]int, len(calls))
+	for i := range order {
+		order[i] = i
+	}
+	if seed != 0 {
+		rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+	}
+
+	var wg sync.WaitGroup
+	for _, i := range order {
+		delay := time.Duration(rng.Intn(2000)) * time.Microsecond
+		var call func()
+		switch calls[i] {
+%s		default:
+			judgeFail("unknown method %%q", calls[i])
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			time.Sleep(delay)
+			call()
+		}()
+	}
+	wg.Wait()
+
+	return out.String()
+}
+
+func judgeFail(format string, a ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", a...)
+	os.Exit(2)
+}
+`, spec.Runs, spec.ClassName, cases.String()), goSolution(code), nil
+}
+
+// exportedName capitalizes a method name for Go
+func exportedName(name string) string {
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// concurrencyChecker requires every run of the harness to satisfy the spec,
+// reporting the first failing run together with its seed
+func concurrencyChecker(spec problemDomain.ConcurrencySpec) outputChecker {
+	return func(ctx context.Context, tc problemDomain.TestCase, output string) (string, bool, error) {
+		output = strings.TrimSpace(output)
+
+		var runs []string
+		if err := json.Unmarshal([]byte(output), &runs); err != nil || len(runs) == 0 {
+			return output, false, nil
+		}
+		var calls []string
+		if err := json.Unmarshal([]byte(tc.Input), &calls); err != nil {
+			return output, false, fmt.Errorf("invalid test input: %w", err)
+		}
+
+		for seed, run := range runs {
+			if !concurrencyRunValid(spec, calls, run, strings.TrimSpace(tc.Expected)) {
+				return fmt.Sprintf("%s (run with seed %d)", run, seed), false, nil
+			}
+		}
+		return runs[0], true, nil
+	}
+}
+
+// concurrencyRunValid checks a single run against the spec's ordering or invariant
+func concurrencyRunValid(spec problemDomain.ConcurrencySpec, calls []string, run, expected string) bool {
+	if spec.Invariant == "" {
+		return run == expected
+	}
+
+	// Every thread must emit its token exactly once
+	var want []byte
+	for _, call := range calls {
+		want = append(want, spec.Tokens[call]...)
+	}
+	if sortedString(run) != sortedString(string(want)) {
+		return false
+	}
+
+	group := len(spec.Invariant)
+	if len(run)%group != 0 {
+		return false
+	}
+	for i := 0; i < len(run); i += group {
+		if sortedString(run[i:i+group]) != sortedString(spec.Invariant) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedString returns s with its bytes in ascending order
+func sortedString(s string) string {
+	b := []byte(s)
+	sort.Slice(b, func(i, j int) bool { return b[i] < b[j] })
+	return string(b)
+}
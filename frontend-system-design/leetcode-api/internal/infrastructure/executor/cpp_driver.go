@@ -0,0 +1,311 @@
+// Package executor provides the C++ driver.
+package executor
+
+import (
+	"fmt"
+	"strings"
+
+	problemDomain "leetcode-api/internal/domain/problem"
+)
+
+// cppType maps a signature type to its C++ equivalent
+func cppType(t problemDomain.ValueType) (string, error) {
+	if t.IsArray() {
+		elem, err := cppType(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "vector<" + elem + ">", nil
+	}
+
+	switch t {
+	case problemDomain.TypeInteger:
+		return "int", nil
+	case problemDomain.TypeLong:
+		return "long long", nil
+	case problemDomain.TypeDouble:
+		return "double", nil
+	case problemDomain.TypeBoolean:
+		return "bool", nil
+	case problemDomain.TypeString:
+		return "string", nil
+	case problemDomain.TypeCharacter:
+		return "char", nil
+	case problemDomain.TypeListNode:
+		return "ListNode*", nil
+	case problemDomain.TypeTreeNode:
+		return "TreeNode*", nil
+	}
+	return "", fmt.Errorf("type %s is not supported in C++", t)
+}
+
+// wrapCpp generates a harness that includes the user's Solution class,
+// decodes the JSON argument list from stdin, calls the signature's method
+// and writes the JSON-encoded result to fd 3. The solution stays in its own
+// file so compiler diagnostics point at the user's lines.
+func wrapCpp(code string, sig problemDomain.Signature) (string, string, error) {
+	var args strings.Builder
+	callArgs := make([]string, len(sig.Params))
+	for i, p := range sig.Params {
+		typ, err := cppType(p.Type)
+		if err != nil {
+			return "", "", fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		callArgs[i] = fmt.Sprintf("arg%d", i)
+		if i > 0 {
+			args.WriteString("    in.expect(',');\n")
+		}
+		fmt.Fprintf(&args, "    %s arg%d;\n    in.read(arg%d);\n", typ, i, i)
+	}
+	call := fmt.Sprintf("solution.%s(%s)", sig.FunctionName, strings.Join(callArgs, ", "))
+
+	var emit string
+	if out := sig.Output(); out >= 0 {
+		// The argument the call modified in place is judged
+		emit = fmt.Sprintf("    %s;\n    std::string out;\n    judge::write(out, arg%d);\n", call, out)
+	} else if sig.ReturnType == problemDomain.TypeVoid {
+		emit = fmt.Sprintf("    %s;\n    std::string out = \"null\";\n", call)
+	} else {
+		if _, err := cppType(sig.ReturnType); err != nil {
+			return "", "", fmt.Errorf("return type: %w", err)
+		}
+		emit = fmt.Sprintf("    auto result = %s;\n    std::string out;\n    judge::write(out, result);\n", call)
+	}
+
+	return cppPrelude + `#include "solution.cpp"
+
+int main() {
+    std::string input((std::istreambuf_iterator<char>(std::cin)), std::istreambuf_iterator<char>());
+    judge::Parser in{input};
+    in.expect('[');
+` + args.String() + `    in.expect(']');
+
+    Solution solution;
+` + emit + `    out += '\n';
+    judge::emit(out);
+    return 0;
+}
+`, code, nil
+}
+
+// cppPrelude gives solutions LeetCode's implicit includes and node types, and
+// holds the harness's JSON reader and writer. Lists are read and written as
+// their values, trees in level order with null for missing children.
+const cppPrelude = `#include <bits/stdc++.h>
+using namespace std;
+
+struct ListNode {
+    int val;
+    ListNode *next;
+    ListNode() : val(0), next(nullptr) {}
+    ListNode(int x) : val(x), next(nullptr) {}
+    ListNode(int x, ListNode *next) : val(x), next(next) {}
+};
+
+struct TreeNode {
+    int val;
+    TreeNode *left;
+    TreeNode *right;
+    TreeNode() : val(0), left(nullptr), right(nullptr) {}
+    TreeNode(int x) : val(x), left(nullptr), right(nullptr) {}
+    TreeNode(int x, TreeNode *left, TreeNode *right) : val(x), left(left), right(right) {}
+};
+
+namespace judge {
+
+struct Parser {
+    std::string s;
+    size_t pos = 0;
+
+    [[noreturn]] void fail(const std::string& what) {
+        std::fprintf(stderr, "invalid test input: %s\n", what.c_str());
+        std::exit(2);
+    }
+    void ws() {
+        while (pos < s.size() && std::isspace((unsigned char)s[pos])) pos++;
+    }
+    bool peek(char c) {
+        ws();
+        return pos < s.size() && s[pos] == c;
+    }
+    void expect(char c) {
+        if (!peek(c)) fail(std::string("expected '") + c + "'");
+        pos++;
+    }
+    std::string number() {
+        ws();
+        size_t start = pos;
+        while (pos < s.size() && std::strchr("+-0123456789.eE", s[pos])) pos++;
+        if (start == pos) fail("expected a number");
+        return s.substr(start, pos - start);
+    }
+
+    void read(int& v) { v = (int)std::strtoll(number().c_str(), nullptr, 10); }
+    void read(long long& v) { v = std::strtoll(number().c_str(), nullptr, 10); }
+    void read(double& v) { v = std::strtod(number().c_str(), nullptr); }
+    void read(bool& v) {
+        ws();
+        if (s.compare(pos, 4, "true") == 0) { v = true; pos += 4; }
+        else if (s.compare(pos, 5, "false") == 0) { v = false; pos += 5; }
+        else fail("expected a boolean");
+    }
+    void read(std::string& v) {
+        expect('"');
+        v.clear();
+        while (pos < s.size() && s[pos] != '"') {
+            char c = s[pos++];
+            if (c != '\\' || pos >= s.size()) { v += c; continue; }
+            char e = s[pos++];
+            switch (e) {
+            case 'n': v += '\n'; break;
+            case 't': v += '\t'; break;
+            case 'r': v += '\r'; break;
+            case 'b': v += '\b'; break;
+            case 'f': v += '\f'; break;
+            case 'u': {
+                unsigned cp = std::stoul(s.substr(pos, 4), nullptr, 16);
+                pos += 4;
+                if (cp < 0x80) v += (char)cp;
+                else if (cp < 0x800) { v += (char)(0xC0 | cp >> 6); v += (char)(0x80 | (cp & 0x3F)); }
+                else { v += (char)(0xE0 | cp >> 12); v += (char)(0x80 | (cp >> 6 & 0x3F)); v += (char)(0x80 | (cp & 0x3F)); }
+                break;
+            }
+            default: v += e;
+            }
+        }
+        if (pos >= s.size()) fail("unterminated string");
+        pos++;
+    }
+    void read(char& v) {
+        std::string str;
+        read(str);
+        if (str.size() != 1) fail("expected a single character");
+        v = str[0];
+    }
+    void read(ListNode*& v) {
+        std::vector<int> values;
+        read(values);
+        v = nullptr;
+        for (size_t i = values.size(); i-- > 0;) v = new ListNode(values[i], v);
+    }
+    void read(TreeNode*& v) {
+        std::vector<TreeNode*> nodes;
+        expect('[');
+        if (peek(']')) pos++;
+        else {
+            while (true) {
+                ws();
+                if (s.compare(pos, 4, "null") == 0) {
+                    pos += 4;
+                    nodes.push_back(nullptr);
+                } else {
+                    int val;
+                    read(val);
+                    nodes.push_back(new TreeNode(val));
+                }
+                if (!peek(',')) break;
+                pos++;
+            }
+            expect(']');
+        }
+        for (size_t i = 0, child = 1; i < nodes.size() && child < nodes.size(); i++) {
+            if (nodes[i] == nullptr) continue;
+            nodes[i]->left = nodes[child++];
+            if (child < nodes.size()) nodes[i]->right = nodes[child++];
+        }
+        v = nodes.empty() ? nullptr : nodes[0];
+    }
+    template <typename T>
+    void read(std::vector<T>& v) {
+        expect('[');
+        v.clear();
+        if (peek(']')) { pos++; return; }
+        while (true) {
+            T item;
+            read(item);
+            v.push_back(item);
+            if (!peek(',')) break;
+            pos++;
+        }
+        expect(']');
+    }
+};
+
+inline void write(std::string& out, long long v) { out += std::to_string(v); }
+inline void write(std::string& out, int v) { out += std::to_string(v); }
+inline void write(std::string& out, long v) { out += std::to_string(v); }
+inline void write(std::string& out, unsigned v) { out += std::to_string(v); }
+inline void write(std::string& out, unsigned long v) { out += std::to_string(v); }
+inline void write(std::string& out, unsigned long long v) { out += std::to_string(v); }
+inline void write(std::string& out, bool v) { out += v ? "true" : "false"; }
+inline void write(std::string& out, double v) {
+    char buf[32];
+    std::snprintf(buf, sizeof buf, "%.17g", v);
+    out += buf;
+}
+inline void write(std::string& out, float v) { write(out, (double)v); }
+inline void write(std::string& out, const std::string& v) {
+    out += '"';
+    for (unsigned char c : v) {
+        if (c == '"' || c == '\\') { out += '\\'; out += (char)c; }
+        else if (c == '\n') out += "\\n";
+        else if (c == '\t') out += "\\t";
+        else if (c == '\r') out += "\\r";
+        else if (c < 0x20) { char buf[8]; std::snprintf(buf, sizeof buf, "\\u%04x", c); out += buf; }
+        else out += (char)c;
+    }
+    out += '"';
+}
+inline void write(std::string& out, const char* v) { write(out, std::string(v)); }
+inline void write(std::string& out, char v) { write(out, std::string(1, v)); }
+inline void write(std::string& out, ListNode* head) {
+    out += '[';
+    for (ListNode* node = head; node != nullptr; node = node->next) {
+        if (node != head) out += ',';
+        write(out, node->val);
+    }
+    out += ']';
+}
+inline void write(std::string& out, TreeNode* root) {
+    std::vector<TreeNode*> order;
+    if (root != nullptr) order.push_back(root);
+    for (size_t i = 0; i < order.size(); i++) {
+        if (order[i] != nullptr) {
+            order.push_back(order[i]->left);
+            order.push_back(order[i]->right);
+        }
+    }
+    while (!order.empty() && order.back() == nullptr) order.pop_back();
+    out += '[';
+    for (size_t i = 0; i < order.size(); i++) {
+        if (i > 0) out += ',';
+        if (order[i] == nullptr) out += "null";
+        else write(out, order[i]->val);
+    }
+    out += ']';
+}
+template <typename T>
+void write(std::string& out, const std::vector<T>& v) {
+    out += '[';
+    bool first = true;
+    for (const auto& item : v) {
+        if (!first) out += ',';
+        first = false;
+        write(out, item);
+    }
+    out += ']';
+}
+
+inline void emit(const std::string& out) {
+    FILE* result = fdopen(3, "w");
+    if (result == nullptr) {
+        std::fprintf(stderr, "cannot open result descriptor\n");
+        std::exit(2);
+    }
+    std::fwrite(out.data(), 1, out.size(), result);
+    std::fclose(result);
+}
+
+}  // namespace judge
+
+`
@@ -0,0 +1,493 @@
+// Package executor provides the harnesses for design problems.
+package executor
+
+import (
+	"fmt"
+	"strings"
+
+	problemDomain "leetcode-api/internal/domain/problem"
+)
+
+// Design harnesses read LeetCode's operation and argument arrays from stdin,
+// construct the user's class with the first operation's arguments and call
+// its methods with the rest in order. They write the array of results to
+// fd 3, with null for the constructor and void methods, and exit with
+// status 2 on an operation the spec does not declare. Each result is encoded
+// when its call returns, as later calls may change what it refers to.
+
+// wrapJavaScriptDesign constructs the class with new, which suits both
+// ES classes and LeetCode's constructor function templates
+func wrapJavaScriptDesign(code string, spec problemDomain.DesignSpec) (string, string, error) {
+	var calls strings.Builder
+	for _, m := range spec.Methods {
+		args := make([]string, len(m.Params))
+		for i, p := range m.Params {
+			args[i] = jsConversion(p.Type, fmt.Sprintf("a[%d]", i), 0, "judgeList", "judgeTree")
+		}
+		call := fmt.Sprintf("obj.%s(%s)", m.FunctionName, strings.Join(args, ", "))
+		if m.ReturnType == problemDomain.TypeVoid {
+			fmt.Fprintf(&calls, "    %s: (a) => {\n      %s;\n      return null;\n    },\n", m.FunctionName, call)
+			continue
+		}
+		result := jsConversion(m.ReturnType, "result", 0, "judgeListValues", "judgeTreeValues")
+		fmt.Fprintf(&calls, "    %s: (a) => {\n      const result = %s;\n      return result === undefined ? null : %s;\n    },\n",
+			m.FunctionName, call, result)
+	}
+	args := make([]string, len(spec.Constructor))
+	for i, p := range spec.Constructor {
+		args[i] = jsConversion(p.Type, fmt.Sprintf("args[0][%d]", i), 0, "judgeList", "judgeTree")
+	}
+
+	return fmt.Sprintf(`%s
+;(() => {
+  const fs = require('fs');
+%s
+  const judgeFail = (message) => {
+    process.stderr.write('invalid test input: ' + message + '\n');
+    process.exit(2);
+  };
+  const [operations, args] = JSON.parse(fs.readFileSync(0, 'utf8'));
+  if (operations.length !== args.length) judgeFail('expected one argument array per operation');
+  if (operations[0] !== %q) judgeFail('the first operation must construct %s');
+  const obj = new %s(%s);
+  const calls = {
+%s  };
+  const results = ['null'];
+  for (let i = 1; i < operations.length; i++) {
+    if (!Object.prototype.hasOwnProperty.call(calls, operations[i])) {
+      judgeFail('unknown operation ' + operations[i]);
+    }
+    results.push(JSON.stringify(calls[operations[i]](args[i])));
+  }
+  fs.writeSync(3, '[' + results.join(',') + ']\n');
+})();
+`, code, jsNodes, spec.ClassName, spec.ClassName, spec.ClassName, strings.Join(args, ", "), calls.String()), "", nil
+}
+
+// wrapPythonDesign executes the user's code from its own file, like
+// wrapPython, and calls the methods under their camelCase names as
+// LeetCode's Python templates declare them
+func wrapPythonDesign(code string, spec problemDomain.DesignSpec) (string, string, error) {
+	var calls strings.Builder
+	for i, m := range spec.Methods {
+		keyword := "elif"
+		if i == 0 {
+			keyword = "if"
+		}
+		args := make([]string, len(m.Params))
+		for j, p := range m.Params {
+			args[j] = pythonConversion(p.Type, fmt.Sprintf("args[%d]", j), 0, "_judge_list", "_judge_tree")
+		}
+		call := fmt.Sprintf("obj.%s(%s)", m.FunctionName, strings.Join(args, ", "))
+		fmt.Fprintf(&calls, "        %s operation == %q:\n", keyword, m.FunctionName)
+		if m.ReturnType == problemDomain.TypeVoid {
+			fmt.Fprintf(&calls, "            %s\n            results.append('null')\n", call)
+			continue
+		}
+		result := pythonConversion(m.ReturnType, "result", 0, "_judge_list_values", "_judge_tree_values")
+		fmt.Fprintf(&calls, "            result = %s\n            results.append(json.dumps(%s, separators=(',', ':')))\n", call, result)
+	}
+	args := make([]string, len(spec.Constructor))
+	for i, p := range spec.Constructor {
+		args[i] = pythonConversion(p.Type, fmt.Sprintf("arguments[0][%d]", i), 0, "_judge_list", "_judge_tree")
+	}
+
+	return pythonPrelude + fmt.Sprintf(`
+
+with open(os.path.join(os.path.dirname(os.path.abspath(__file__)), 'solution.py')) as _judge_source:
+    exec(compile(_judge_source.read(), 'solution.py', 'exec'))
+
+
+def _judge_fail(message):
+    print('invalid test input: ' + message, file=sys.stderr)
+    sys.exit(2)
+
+
+def _judge_main():
+    operations, arguments = json.load(sys.stdin)
+    if len(operations) != len(arguments):
+        _judge_fail('expected one argument array per operation')
+    if not operations or operations[0] != %q:
+        _judge_fail('the first operation must construct %s')
+    obj = %s(%s)
+    results = ['null']
+    for operation, args in zip(operations[1:], arguments[1:]):
+%s        else:
+            _judge_fail('unknown operation %%s' %% operation)
+    with os.fdopen(3, 'w') as out:
+        out.write('[' + ','.join(results) + ']\n')
+
+
+_judge_main()
+`, spec.ClassName, spec.ClassName, spec.ClassName, strings.Join(args, ", "), calls.String()), code, nil
+}
+
+// wrapGoDesign follows LeetCode's Go templates: a Constructor function
+// returns the object, whose methods are exported
+func wrapGoDesign(code string, spec problemDomain.DesignSpec) (string, string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, `package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+func main() {
+	var calls []json.RawMessage
+	if err := json.NewDecoder(os.Stdin).Decode(&calls); err != nil || len(calls) != 2 {
+		judgeFail("invalid test input: expected the operation and argument arrays")
+	}
+	var operations []string
+	judgeDecode(calls[0], &operations)
+	var args [][]json.RawMessage
+	judgeDecode(calls[1], &args)
+	if len(operations) != len(args) {
+		judgeFail("invalid test input: expected one argument array per operation")
+	}
+	if len(operations) == 0 || operations[0] != %q {
+		judgeFail("invalid test input: the first operation must construct %s")
+	}
+`, spec.ClassName, spec.ClassName)
+
+	decls, names, err := goDesignArgs(spec.Constructor, "args[0]", "\t")
+	if err != nil {
+		return "", "", fmt.Errorf("constructor: %w", err)
+	}
+	fmt.Fprintf(&b, "\tjudgeArgs(%q, args[0], %d)\n%s\tobj := Constructor(%s)\n",
+		spec.ClassName, len(spec.Constructor), decls, strings.Join(names, ", "))
+
+	b.WriteString("\tresults := []json.RawMessage{json.RawMessage(\"null\")}\n\tfor i := 1; i < len(operations); i++ {\n\t\tswitch operations[i] {\n")
+	for _, m := range spec.Methods {
+		decls, names, err := goDesignArgs(m.Params, "args[i]", "\t\t\t")
+		if err != nil {
+			return "", "", fmt.Errorf("method %s: %w", m.FunctionName, err)
+		}
+		call := fmt.Sprintf("obj.%s(%s)", exportedName(m.FunctionName), strings.Join(names, ", "))
+		fmt.Fprintf(&b, "\t\tcase %q:\n\t\t\tjudgeArgs(operations[i], args[i], %d)\n%s", m.FunctionName, len(m.Params), decls)
+
+		switch {
+		case m.ReturnType == problemDomain.TypeVoid:
+			fmt.Fprintf(&b, "\t\t\t%s\n\t\t\tresults = append(results, json.RawMessage(\"null\"))\n", call)
+		case m.ReturnType.Base().IsNode():
+			fmt.Fprintf(&b, "\t\t\tresult := %s\n\t\t\tresults = append(results, judgeJSON(%s))\n", call, goEncode(m.ReturnType, "result", 0))
+		case m.ReturnType.IsArray():
+			typ, err := goType(m.ReturnType)
+			if err != nil {
+				return "", "", fmt.Errorf("method %s: return type: %w", m.FunctionName, err)
+			}
+			// A nil slice is the idiomatic empty result but encodes as null
+			fmt.Fprintf(&b, "\t\t\tresult := %s\n\t\t\tif result == nil {\n\t\t\t\tresult = %s{}\n\t\t\t}\n\t\t\tresults = append(results, judgeJSON(result))\n", call, typ)
+		default:
+			if _, err := goType(m.ReturnType); err != nil {
+				return "", "", fmt.Errorf("method %s: return type: %w", m.FunctionName, err)
+			}
+			fmt.Fprintf(&b, "\t\t\tresults = append(results, judgeJSON(%s))\n", call)
+		}
+	}
+	b.WriteString(`		default:
+			judgeFail("invalid test input: unknown operation %s", operations[i])
+		}
+	}
+	judgeWrite(results)
+}
+
+func judgeArgs(operation string, args []json.RawMessage, n int) {
+	if len(args) != n {
+		judgeFail("invalid test input: %s expects %d arguments, got %d", operation, n, len(args))
+	}
+}
+
+func judgeJSON(v interface{}) json.RawMessage {
+	out, err := json.Marshal(v)
+	if err != nil {
+		judgeFail("cannot encode result: %v", err)
+	}
+	return out
+}
+`)
+	b.WriteString(goJudge)
+	b.WriteString(goNodes)
+
+	return b.String(), goSolution(code), nil
+}
+
+// goDesignArgs declares the arguments of one call, decoded from the
+// elements of args
+func goDesignArgs(params []problemDomain.Param, args, indent string) (string, []string, error) {
+	var b strings.Builder
+	names := make([]string, len(params))
+	for i, p := range params {
+		typ, err := goType(p.Type)
+		if err != nil {
+			return "", nil, fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		names[i] = fmt.Sprintf("arg%d", i)
+		raw := fmt.Sprintf("%s[%d]", args, i)
+		if p.Type.Base().IsNode() {
+			decode, err := goDecode(p.Type, raw, 0)
+			if err != nil {
+				return "", nil, fmt.Errorf("parameter %s: %w", p.Name, err)
+			}
+			fmt.Fprintf(&b, "%sarg%d := %s\n", indent, i, decode)
+			continue
+		}
+		fmt.Fprintf(&b, "%svar arg%d %s\n%sjudgeDecode(%s, &arg%d)\n", indent, i, typ, indent, raw, i)
+	}
+	return b.String(), names, nil
+}
+
+// wrapCppDesign parses the argument array alongside the calls, so each
+// argument is read into its parameter's type. The object is allocated with
+// new, as LeetCode's C++ templates show.
+func wrapCppDesign(code string, spec problemDomain.DesignSpec) (string, string, error) {
+	decls, names, err := cppDesignArgs(spec.Constructor, "    ")
+	if err != nil {
+		return "", "", fmt.Errorf("constructor: %w", err)
+	}
+	construct := fmt.Sprintf("    in.expect('[');\n%s    in.expect(']');\n    %s* obj = new %s(%s);\n",
+		decls, spec.ClassName, spec.ClassName, strings.Join(names, ", "))
+
+	var calls strings.Builder
+	for i, m := range spec.Methods {
+		decls, names, err := cppDesignArgs(m.Params, "            ")
+		if err != nil {
+			return "", "", fmt.Errorf("method %s: %w", m.FunctionName, err)
+		}
+		if i > 0 {
+			calls.WriteString(" else ")
+		} else {
+			calls.WriteString("        ")
+		}
+		fmt.Fprintf(&calls, "if (op == %q) {\n%s            in.expect(']');\n", m.FunctionName, decls)
+		call := fmt.Sprintf("obj->%s(%s)", m.FunctionName, strings.Join(names, ", "))
+		if m.ReturnType == problemDomain.TypeVoid {
+			fmt.Fprintf(&calls, "            %s;\n            out += \",null\";\n        }", call)
+			continue
+		}
+		if _, err := cppType(m.ReturnType); err != nil {
+			return "", "", fmt.Errorf("method %s: return type: %w", m.FunctionName, err)
+		}
+		fmt.Fprintf(&calls, "            out += ',';\n            judge::write(out, %s);\n        }", call)
+	}
+
+	return cppPrelude + `#include "solution.cpp"
+
+int main() {
+    std::string input((std::istreambuf_iterator<char>(std::cin)), std::istreambuf_iterator<char>());
+    judge::Parser in{input};
+    in.expect('[');
+    std::vector<std::string> operations;
+    in.read(operations);
+    in.expect(',');
+    in.expect('[');
+    if (operations.empty() || operations[0] != "` + spec.ClassName + `") {
+        in.fail("the first operation must construct ` + spec.ClassName + `");
+    }
+` + construct + `
+    std::string out = "[null";
+    for (size_t i = 1; i < operations.size(); i++) {
+        const std::string& op = operations[i];
+        in.expect(',');
+        in.expect('[');
+` + calls.String() + ` else {
+            in.fail("unknown operation " + op);
+        }
+    }
+    in.expect(']');
+    in.expect(']');
+    out += "]\n";
+    judge::emit(out);
+    return 0;
+}
+`, code, nil
+}
+
+// cppDesignArgs declares and reads the arguments of one call
+func cppDesignArgs(params []problemDomain.Param, indent string) (string, []string, error) {
+	var b strings.Builder
+	names := make([]string, len(params))
+	for i, p := range params {
+		typ, err := cppType(p.Type)
+		if err != nil {
+			return "", nil, fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		names[i] = fmt.Sprintf("arg%d", i)
+		if i > 0 {
+			fmt.Fprintf(&b, "%sin.expect(',');\n", indent)
+		}
+		fmt.Fprintf(&b, "%s%s arg%d;\n%sin.read(arg%d);\n", indent, typ, i, indent, i)
+	}
+	return b.String(), names, nil
+}
+
+// wrapJavaDesign constructs the user's class, declared in Solution.java,
+// and dispatches each operation with a switch
+func wrapJavaDesign(code string, spec problemDomain.DesignSpec) (string, string, error) {
+	decls, names, err := javaDesignArgs(spec.Constructor, "            ")
+	if err != nil {
+		return "", "", fmt.Errorf("constructor: %w", err)
+	}
+	// Java forbids shadowing locals, so each call's arguments get their own block
+	construct := fmt.Sprintf("        %s obj;\n        {\n            List<Object> args = Judge.args(arguments.get(0), %d);\n%s            obj = new %s(%s);\n        }\n",
+		spec.ClassName, len(spec.Constructor), decls, spec.ClassName, strings.Join(names, ", "))
+
+	var calls strings.Builder
+	for _, m := range spec.Methods {
+		decls, names, err := javaDesignArgs(m.Params, "                    ")
+		if err != nil {
+			return "", "", fmt.Errorf("method %s: %w", m.FunctionName, err)
+		}
+		fmt.Fprintf(&calls, "                case %q: {\n                    List<Object> args = Judge.args(arguments.get(i), %d);\n%s",
+			m.FunctionName, len(m.Params), decls)
+		call := fmt.Sprintf("obj.%s(%s)", m.FunctionName, strings.Join(names, ", "))
+		if m.ReturnType == problemDomain.TypeVoid {
+			fmt.Fprintf(&calls, "                    %s;\n                    out.append(\",null\");\n", call)
+		} else {
+			if _, err := javaType(m.ReturnType); err != nil {
+				return "", "", fmt.Errorf("method %s: return type: %w", m.FunctionName, err)
+			}
+			if m.ReturnType.Base().IsNode() {
+				call = javaEncoding(m.ReturnType, call, 0)
+			}
+			fmt.Fprintf(&calls, "                    out.append(',');\n                    Judge.write(out, %s);\n", call)
+		}
+		calls.WriteString("                    break;\n                }\n")
+	}
+
+	harness := `import java.io.*;
+import java.nio.charset.StandardCharsets;
+import java.util.*;
+
+public class Main {
+    public static void main(String[] argv) throws IOException {
+        String input = new String(System.in.readAllBytes(), StandardCharsets.UTF_8);
+        List<Object> calls = Judge.list(new Judge(input).parse());
+        if (calls.size() != 2) Judge.fail("expected the operation and argument arrays");
+        List<Object> operations = Judge.list(calls.get(0));
+        List<Object> arguments = Judge.list(calls.get(1));
+        if (operations.size() != arguments.size()) Judge.fail("expected one argument array per operation");
+        if (operations.isEmpty() || !"` + spec.ClassName + `".equals(operations.get(0))) {
+            Judge.fail("the first operation must construct ` + spec.ClassName + `");
+        }
+` + construct + `
+        StringBuilder out = new StringBuilder("[null");
+        for (int i = 1; i < operations.size(); i++) {
+            String op = String.valueOf(operations.get(i));
+            switch (op) {
+` + calls.String() + `                default:
+                    Judge.fail("unknown operation " + op);
+            }
+        }
+
+        out.append("]\n");
+        try (OutputStream stream = new FileOutputStream("/proc/self/fd/3")) {
+            stream.write(out.toString().getBytes(StandardCharsets.UTF_8));
+        }
+    }
+}
+` + javaJudge
+
+	return harness, "import java.util.*;\n\n" + code, nil
+}
+
+// javaDesignArgs declares the arguments of one call, converted from args
+func javaDesignArgs(params []problemDomain.Param, indent string) (string, []string, error) {
+	var b strings.Builder
+	names := make([]string, len(params))
+	for i, p := range params {
+		typ, err := javaType(p.Type)
+		if err != nil {
+			return "", nil, fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		conversion, err := javaConversion(p.Type, fmt.Sprintf("args.get(%d)", i), 0)
+		if err != nil {
+			return "", nil, fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		names[i] = fmt.Sprintf("arg%d", i)
+		fmt.Fprintf(&b, "%s%s arg%d = %s;\n", indent, typ, i, conversion)
+	}
+	return b.String(), names, nil
+}
+
+// wrapRustDesign follows LeetCode's Rust templates: the struct's new
+// function constructs it and methods have snake_case names. Like
+// wrapCppDesign, it parses the argument array alongside the calls.
+func wrapRustDesign(code string, spec problemDomain.DesignSpec) (string, string, error) {
+	decls, names, err := rustDesignArgs(spec.Constructor, "    ")
+	if err != nil {
+		return "", "", fmt.Errorf("constructor: %w", err)
+	}
+	construct := fmt.Sprintf("    parser.expect(b'[');\n%s    parser.expect(b']');\n    let mut obj = %s::new(%s);\n",
+		decls, spec.ClassName, strings.Join(names, ", "))
+
+	var calls strings.Builder
+	for _, m := range spec.Methods {
+		decls, names, err := rustDesignArgs(m.Params, "                ")
+		if err != nil {
+			return "", "", fmt.Errorf("method %s: %w", m.FunctionName, err)
+		}
+		fmt.Fprintf(&calls, "            %q => {\n%s                parser.expect(b']');\n", m.FunctionName, decls)
+		call := fmt.Sprintf("obj.%s(%s)", rustName(m.FunctionName), strings.Join(names, ", "))
+		if m.ReturnType == problemDomain.TypeVoid {
+			fmt.Fprintf(&calls, "                %s;\n                out.push_str(\",null\");\n            }\n", call)
+			continue
+		}
+		if _, err := rustType(m.ReturnType); err != nil {
+			return "", "", fmt.Errorf("method %s: return type: %w", m.FunctionName, err)
+		}
+		fmt.Fprintf(&calls, "                let result = %s;\n                out.push(',');\n                judge::ToJson::to_json(&result, &mut out);\n            }\n", call)
+	}
+
+	return `#![allow(dead_code, unused_imports, unused_mut, non_snake_case)]
+` + rustNodes + `
+include!("solution.rs");
+
+fn main() {
+    let mut input = String::new();
+    if let Err(err) = std::io::Read::read_to_string(&mut std::io::stdin(), &mut input) {
+        judge::fail(&err.to_string());
+    }
+    let mut parser = judge::Parser::new(&input);
+    parser.expect(b'[');
+    let operations: Vec<String> = parser.read();
+    parser.expect(b',');
+    parser.expect(b'[');
+    if operations.first().map(String::as_str) != Some("` + spec.ClassName + `") {
+        judge::fail("the first operation must construct ` + spec.ClassName + `");
+    }
+` + construct + `
+    let mut out = String::from("[null");
+    for op in &operations[1..] {
+        parser.expect(b',');
+        parser.expect(b'[');
+        match op.as_str() {
+` + calls.String() + `            _ => judge::fail(&format!("unknown operation {}", op)),
+        }
+    }
+    parser.expect(b']');
+    parser.expect(b']');
+    out.push_str("]\n");
+    judge::emit(&out);
+}
+` + rustJudge, code, nil
+}
+
+// rustDesignArgs declares and reads the arguments of one call
+func rustDesignArgs(params []problemDomain.Param, indent string) (string, []string, error) {
+	var b strings.Builder
+	names := make([]string, len(params))
+	for i, p := range params {
+		typ, err := rustType(p.Type)
+		if err != nil {
+			return "", nil, fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		names[i] = fmt.Sprintf("arg%d", i)
+		if i > 0 {
+			fmt.Fprintf(&b, "%sparser.expect(b',');\n", indent)
+		}
+		fmt.Fprintf(&b, "%slet arg%d: %s = parser.read();\n", indent, i, typ)
+	}
+	return b.String(), names, nil
+}
@@ -0,0 +1,510 @@
+// Package executor provides the driver templates that turn solutions into runnable programs.
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	problemDomain "leetcode-api/internal/domain/problem"
+	"leetcode-api/internal/infrastructure/sandbox"
+)
+
+// sqlDriver names the driver of database languages, which are judged
+// inside the server rather than run as programs
+const sqlDriver = "sql"
+
+// driverTemplate generates the sources of programs for one language family.
+// A template returns the harness, written to main<ext>, and optionally the
+// solution as a separate file, written to solution<ext>; when the solution is
+// empty the harness embeds the user's code.
+type driverTemplate struct {
+	// harnessName and solutionName replace the default base names of the
+	// source files, for languages whose file names follow class names
+	harnessName, solutionName string
+	// function wraps a solution called through the problem's signature
+	function func(code string, sig problemDomain.Signature) (harness, solution string, err error)
+	// concurrency wraps a solution of a concurrency problem; nil when unsupported
+	concurrency func(code string, spec problemDomain.ConcurrencySpec) (harness, solution string, err error)
+	// design wraps the class of a design problem; nil when unsupported
+	design func(code string, spec problemDomain.DesignSpec) (harness, solution string, err error)
+	// program splits a full program, which runs as-is without a harness,
+	// into the template's files; nil writes it as the harness
+	program func(code string) (harness, solution string)
+	// script runs the submission as-is and judges its stdout
+	script bool
+	// prepare stages anything else the program needs in its directory
+	prepare func(dir string) error
+}
+
+// driverTemplates are the templates runner definitions can refer to by name.
+// They are compiled in: runtimes of these families only need a definition.
+var driverTemplates = map[string]driverTemplate{
+	"javascript": {function: wrapJavaScript, design: wrapJavaScriptDesign},
+	"python":     {function: wrapPython, concurrency: wrapPythonConcurrency, design: wrapPythonDesign},
+	"go":         {function: wrapGoFunction, concurrency: wrapGoConcurrency, design: wrapGoDesign, program: goProgram},
+	"cpp":        {function: wrapCpp, design: wrapCppDesign},
+	"java":       {function: wrapJava, design: wrapJavaDesign, harnessName: "Main", solutionName: "Solution"},
+	"rust":       {function: wrapRust, design: wrapRustDesign},
+	"shell":      {script: true, prepare: stageShellTools},
+	sqlDriver:    {},
+}
+
+// driverNames lists the names of the driver templates in order
+func driverNames() []string {
+	names := make([]string, 0, len(driverTemplates))
+	for name := range driverTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// build generates the program's sources with the runner's driver template
+// and compiles them once
+func (e *CodeExecutor) build(ctx context.Context, problem *problemDomain.Problem, runner LanguageRunner, code string) (*program, error) {
+	tmpl := driverTemplates[runner.Driver()]
+
+	var harness, solution string
+	var err error
+	switch {
+	case problem.Interactive != nil:
+		// Interactive solutions are full programs talking to the judge
+		if err := problem.Interactive.Validate(); err != nil {
+			return nil, err
+		}
+		harness, solution = tmpl.standalone(code)
+
+	case problem.IOMode:
+		// Full programs read the test input from stdin and print their answer
+		harness, solution = tmpl.standalone(code)
+
+	case tmpl.script:
+		// Scripts read their fixture files directly and need no harness
+		harness = code
+
+	case problem.Concurrency != nil:
+		if tmpl.concurrency == nil {
+			return nil, fmt.Errorf("language %s is not supported for concurrency problems", runner.Name())
+		}
+		spec := *problem.Concurrency
+		if err := spec.Validate(); err != nil {
+			return nil, err
+		}
+		if spec.Runs <= 0 {
+			spec.Runs = defaultConcurrencyRuns
+		}
+		harness, solution, err = tmpl.concurrency(code, spec)
+
+	case problem.Design != nil:
+		if tmpl.design == nil {
+			return nil, fmt.Errorf("language %s is not supported for design problems", runner.Name())
+		}
+		if err := problem.Design.Validate(); err != nil {
+			return nil, err
+		}
+		harness, solution, err = tmpl.design(code, *problem.Design)
+
+	default:
+		if tmpl.function == nil {
+			return nil, fmt.Errorf("language %s cannot call functions", runner.Name())
+		}
+		if problem.Signature == nil {
+			return nil, fmt.Errorf("problem %s has no function signature", problem.Slug)
+		}
+		if err := problem.Signature.Validate(); err != nil {
+			return nil, err
+		}
+		harness, solution, err = tmpl.function(code, *problem.Signature)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	prog, err := e.assemble(ctx, runner, tmpl.sources(runner, harness, solution), tmpl.prepare)
+	if err != nil {
+		return nil, err
+	}
+	prog.stdoutResult = tmpl.script || problem.IOMode
+	prog.memoryLimit = problem.MemoryLimitKB()
+
+	if problem.Interactive != nil {
+		if prog.judge, err = e.judgeProgram(ctx, *problem.Interactive); err != nil {
+			prog.cleanup()
+			return nil, err
+		}
+		prog.queryLimit = problem.Interactive.QueryLimit
+	}
+	return prog, nil
+}
+
+// standalone splits a full program into the template's harness and solution
+func (t driverTemplate) standalone(code string) (harness, solution string) {
+	if t.program != nil {
+		return t.program(code)
+	}
+	return code, ""
+}
+
+// sources names the files a template's harness and solution are written to
+func (t driverTemplate) sources(runner LanguageRunner, harness, solution string) map[string]string {
+	harnessName, solutionName := "main", "solution"
+	if t.harnessName != "" {
+		harnessName = t.harnessName
+	}
+	if t.solutionName != "" {
+		solutionName = t.solutionName
+	}
+
+	files := map[string]string{harnessName + runner.FileExtension(): harness}
+	if solution != "" {
+		files[solutionName+runner.FileExtension()] = solution
+	}
+	return files
+}
+
+// assemble writes a program's sources into a fresh directory and compiles them.
+// The program runs with each test input on stdin, within the executor's time
+// limit scaled by the runner's multiplier.
+func (e *CodeExecutor) assemble(ctx context.Context, runner LanguageRunner, files map[string]string, prepare func(dir string) error) (*program, error) {
+	dir, err := newProgramDir("judge-" + runner.Name() + "-")
+	if err != nil {
+		return nil, err
+	}
+	prog := &program{dir: dir, policy: runner.Policy(), timeout: e.timeout}
+	if multiplier := runner.TimeMultiplier(); multiplier > 0 {
+		prog.timeout = time.Duration(float64(e.timeout) * multiplier)
+	}
+	prog.policy.CPUTime = prog.timeout
+
+	for name, content := range files {
+		// Sandboxed runs may read the sources as another user
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			prog.cleanup()
+			return nil, err
+		}
+	}
+	if prepare != nil {
+		if err := prepare(dir); err != nil {
+			prog.cleanup()
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, compileTimeout)
+	defer cancel()
+	if err := e.compile(ctx, runner, dir); err != nil {
+		prog.cleanup()
+		return nil, err
+	}
+
+	prog.command = func(ctx context.Context, input string) *exec.Cmd {
+		cmd := runner.Command(ctx, dir)
+		cmd.Stdin = strings.NewReader(input)
+		return cmd
+	}
+	return prog, nil
+}
+
+// compile builds the sources in dir with the runner's compile command.
+// Compilers read untrusted sources, which can name any host file through
+// includes, so the compile is confined like the program itself.
+func (e *CodeExecutor) compile(ctx context.Context, runner LanguageRunner, dir string) error {
+	var cache string
+	if runner.BuildCache() {
+		var err error
+		if cache, err = e.buildCache(runner.Name()); err != nil {
+			return err
+		}
+	}
+	// Going over the memory limit cancels the compile
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	cmd := runner.CompileCommand(ctx, dir, cache)
+	if cmd == nil {
+		return nil
+	}
+
+	cmd.Dir = dir
+	cmd.WaitDelay = time.Second
+	if e.sandbox != nil {
+		if err := e.sandbox.Wrap(cmd, compilePolicy, sandbox.Mount{Path: cache, Writable: true}); err != nil {
+			return err
+		}
+	}
+	killProcessGroup(cmd)
+
+	output := &limitedBuffer{limit: maxStderrSize}
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	var exceeded bool
+	err := cmd.Start()
+	if err == nil {
+		watch := watchMemory(cmd.Process.Pid, compileMemoryLimit, cancel)
+		err = cmd.Wait()
+		_, exceeded = watch.stop()
+	}
+	if err == nil {
+		return nil
+	}
+
+	message := strings.TrimSpace(output.String())
+	var exitErr *exec.ExitError
+	switch {
+	case e.sandbox != nil && e.sandbox.HelperFailed(cmd.ProcessState, message):
+		return fmt.Errorf("compile: %s", message)
+	case exceeded:
+		return &CompileError{Output: "Compile Memory Limit Exceeded"}
+	case ctx.Err() == context.DeadlineExceeded || exitSignal(cmd.ProcessState) == "SIGXCPU":
+		return &CompileError{Output: "Compile Time Limit Exceeded"}
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case errors.As(err, &exitErr):
+		if message == "" {
+			message = err.Error()
+		}
+		return &CompileError{Output: message}
+	}
+	return err
+}
+
+// buildCache returns the runner's build cache directory, created on first
+// use and kept for the executor's lifetime so compiles stay warm
+func (e *CodeExecutor) buildCache(name string) (string, error) {
+	e.cacheMu.Lock()
+	defer e.cacheMu.Unlock()
+	if dir, ok := e.caches[name]; ok {
+		return dir, nil
+	}
+	dir, err := newProgramDir("judge-cache-" + name + "-")
+	if err != nil {
+		return "", err
+	}
+	if e.caches == nil {
+		e.caches = make(map[string]string)
+	}
+	e.caches[name] = dir
+	return dir, nil
+}
+
+// wrapJavaScript calls the signature's entry point, either a global function
+// or a method of LeetCode's Solution class, with the arguments read from
+// stdin. The harness runs in its own scope after the user's code, so the code
+// cannot see its variables; it defines LeetCode's ListNode and TreeNode
+// globally unless the code does.
+func wrapJavaScript(code string, sig problemDomain.Signature) (string, string, error) {
+	var decls strings.Builder
+	args := make([]string, len(sig.Params))
+	for i, p := range sig.Params {
+		args[i] = fmt.Sprintf("arg%d", i)
+		fmt.Fprintf(&decls, "  const arg%d = %s;\n", i, jsConversion(p.Type, fmt.Sprintf("args[%d]", i), 0, "judgeList", "judgeTree"))
+	}
+	call := fmt.Sprintf("(typeof Solution === 'function' ? new Solution().%s(%s) : %s(%s))",
+		sig.FunctionName, strings.Join(args, ", "), sig.FunctionName, strings.Join(args, ", "))
+
+	judged, judgedType := fmt.Sprintf("const result = %s;", call), sig.ReturnType
+	if out := sig.Output(); out >= 0 {
+		judged, judgedType = fmt.Sprintf("%s;\n  const result = arg%d;", call, out), sig.Params[out].Type
+	}
+	result := jsConversion(judgedType, "result", 0, "judgeListValues", "judgeTreeValues")
+
+	return fmt.Sprintf(`%s
+;(() => {
+  const fs = require('fs');
+%s
+  const args = JSON.parse(fs.readFileSync(0, 'utf8'));
+%s  %s
+  fs.writeSync(3, JSON.stringify(result === undefined ? null : %s) + '\n');
+})();
+`, code, jsNodes, decls.String(), judged, result), "", nil
+}
+
+// jsConversion returns an expression converting expr between its JSON form
+// and type t, with list and tree naming the harness functions converting
+// single nodes. Values of other types need no conversion.
+func jsConversion(t problemDomain.ValueType, expr string, depth int, list, tree string) string {
+	switch {
+	case t.IsArray() && t.Base().IsNode():
+		param := fmt.Sprintf("v%d", depth)
+		return fmt.Sprintf("%s.map(%s => %s)", expr, param, jsConversion(t.Elem(), param, depth+1, list, tree))
+	case t == problemDomain.TypeListNode:
+		return list + "(" + expr + ")"
+	case t == problemDomain.TypeTreeNode:
+		return tree + "(" + expr + ")"
+	}
+	return expr
+}
+
+// jsNodes defines the node classes and converts them from and to LeetCode's
+// array forms: a list as its values and a tree in level order, with null
+// for missing children
+const jsNodes = `  if (typeof ListNode === 'undefined') {
+    globalThis.ListNode = function ListNode(val, next) {
+      this.val = val === undefined ? 0 : val;
+      this.next = next === undefined ? null : next;
+    };
+  }
+  if (typeof TreeNode === 'undefined') {
+    globalThis.TreeNode = function TreeNode(val, left, right) {
+      this.val = val === undefined ? 0 : val;
+      this.left = left === undefined ? null : left;
+      this.right = right === undefined ? null : right;
+    };
+  }
+  const judgeList = (values) => {
+    let head = null;
+    for (let i = values.length - 1; i >= 0; i--) {
+      const node = new ListNode(values[i]);
+      node.next = head;
+      head = node;
+    }
+    return head;
+  };
+  const judgeListValues = (head) => {
+    const values = [];
+    for (let node = head; node; node = node.next) values.push(node.val);
+    return values;
+  };
+  const judgeTree = (values) => {
+    const nodes = values.map((v) => (v === null ? null : new TreeNode(v)));
+    for (let i = 0, child = 1; i < nodes.length && child < nodes.length; i++) {
+      if (nodes[i] === null) continue;
+      nodes[i].left = nodes[child++];
+      if (child < nodes.length) nodes[i].right = nodes[child++];
+    }
+    return nodes.length ? nodes[0] : null;
+  };
+  const judgeTreeValues = (root) => {
+    const order = root ? [root] : [];
+    for (let i = 0; i < order.length; i++) {
+      if (order[i]) order.push(order[i].left || null, order[i].right || null);
+    }
+    while (order.length && order[order.length - 1] === null) order.pop();
+    return order.map((node) => (node === null ? null : node.val));
+  };`
+
+// wrapPython calls the signature's entry point, either a module-level function
+// or a method of LeetCode's Solution class, with the arguments read from stdin.
+// The user's code is executed from its own file, so tracebacks point at its
+// lines, after the harness defines what LeetCode's environment provides.
+func wrapPython(code string, sig problemDomain.Signature) (string, string, error) {
+	var decls strings.Builder
+	args := make([]string, len(sig.Params))
+	for i, p := range sig.Params {
+		args[i] = fmt.Sprintf("arg%d", i)
+		fmt.Fprintf(&decls, "    arg%d = %s\n", i, pythonConversion(p.Type, fmt.Sprintf("args[%d]", i), 0, "_judge_list", "_judge_tree"))
+	}
+	call := strings.Join(args, ", ")
+
+	var judged string
+	judgedType := sig.ReturnType
+	if out := sig.Output(); out >= 0 {
+		judged, judgedType = fmt.Sprintf("    result = arg%d\n", out), sig.Params[out].Type
+	}
+	result := pythonConversion(judgedType, "result", 0, "_judge_list_values", "_judge_tree_values")
+
+	return pythonPrelude + fmt.Sprintf(`
+
+with open(os.path.join(os.path.dirname(os.path.abspath(__file__)), 'solution.py')) as _judge_source:
+    exec(compile(_judge_source.read(), 'solution.py', 'exec'))
+
+
+def _judge_main():
+    args = json.load(sys.stdin)
+%s    if 'Solution' in globals():
+        result = Solution().%s(%s)
+    else:
+        result = %s(%s)
+%s    with os.fdopen(3, 'w') as out:
+        out.write(json.dumps(%s, separators=(',', ':')) + '\n')
+
+
+_judge_main()
+`, decls.String(), sig.FunctionName, call, sig.FunctionName, call, judged, result), code, nil
+}
+
+// pythonConversion returns an expression converting expr between its JSON
+// form and type t, like jsConversion
+func pythonConversion(t problemDomain.ValueType, expr string, depth int, list, tree string) string {
+	switch {
+	case t.IsArray() && t.Base().IsNode():
+		param := fmt.Sprintf("v%d", depth)
+		return fmt.Sprintf("[%s for %s in %s]", pythonConversion(t.Elem(), param, depth+1, list, tree), param, expr)
+	case t == problemDomain.TypeListNode:
+		return list + "(" + expr + ")"
+	case t == problemDomain.TypeTreeNode:
+		return tree + "(" + expr + ")"
+	}
+	return expr
+}
+
+// pythonPrelude holds the imports and node classes of LeetCode's Python
+// environment, and the conversions of nodes from and to their array forms.
+// The conversions look the classes up when called, so solutions may
+// redefine them.
+const pythonPrelude = `import json
+import os
+import sys
+from typing import *
+
+
+class ListNode:
+    def __init__(self, val=0, next=None):
+        self.val = val
+        self.next = next
+
+
+class TreeNode:
+    def __init__(self, val=0, left=None, right=None):
+        self.val = val
+        self.left = left
+        self.right = right
+
+
+def _judge_list(values):
+    head = None
+    for value in reversed(values):
+        node = ListNode(value)
+        node.next = head
+        head = node
+    return head
+
+
+def _judge_list_values(head):
+    values = []
+    while head is not None:
+        values.append(head.val)
+        head = head.next
+    return values
+
+
+def _judge_tree(values):
+    nodes = [None if value is None else TreeNode(value) for value in values]
+    child = 1
+    for node in nodes:
+        if child >= len(nodes):
+            break
+        if node is None:
+            continue
+        node.left = nodes[child]
+        node.right = nodes[child + 1] if child + 1 < len(nodes) else None
+        child += 2
+    return nodes[0] if nodes else None
+
+
+def _judge_tree_values(root):
+    order = [root] if root is not None else []
+    for node in order:
+        if node is not None:
+            order.append(getattr(node, 'left', None))
+            order.append(getattr(node, 'right', None))
+    while order and order[-1] is None:
+        order.pop()
+    return [None if node is None else node.val for node in order]`
@@ -0,0 +1,59 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	problemDomain "leetcode-api/internal/domain/problem"
+	submissionDomain "leetcode-api/internal/domain/submission"
+)
+
+// Includes are resolved by the compiler, so a submission can name any host
+// file; compiling it confined must not reveal the file or the server's
+// environment in the verdict
+func TestCompileCannotReadHost(t *testing.T) {
+	const token = "judge-token-2c9f"
+	t.Setenv("JUDGE_TOKEN", token)
+	// Other submissions and the server's own files live in the temporary directory
+	secret := filepath.Join(t.TempDir(), "secret.txt")
+	if err := os.WriteFile(secret, []byte("host-secret-71ad"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	e := newSandboxedExecutor(t)
+	problem := &problemDomain.Problem{
+		Slug:      "identity",
+		Signature: &problemDomain.Signature{FunctionName: "f", Params: []problemDomain.Param{{Name: "n", Type: "integer"}}, ReturnType: "integer"},
+	}
+	testCases := []problemDomain.TestCase{{Input: "[1]", Expected: "1"}}
+
+	tests := []struct {
+		name     string
+		language string
+		code     string
+	}{
+		{"cpp include of a temporary file", "cpp", "#include \"" + secret + "\"\nclass Solution { public: int f(int n) { return n; } };"},
+		{"cpp include of the environment", "cpp", "#include \"/proc/self/environ\"\nclass Solution { public: int f(int n) { return n; } };"},
+		{"rust include_str of a temporary file", "rust", "impl Solution { pub fn f(n: i32) -> i32 { print!(\"{}\", include_str!(\"" + secret + "\")); n } }"},
+		{"rust include_str of the environment", "rust", "impl Solution { pub fn f(n: i32) -> i32 { print!(\"{}\", include_str!(\"/proc/self/environ\")); n } }"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !e.languages.Available(tt.language) {
+				t.Skipf("%s is not installed", tt.language)
+			}
+			result := e.Execute(context.Background(), problem, tt.language, tt.code, testCases, nil)[0]
+			if result.Status == submissionDomain.StatusInternal {
+				t.Skipf("%s cannot run confined here: %s", tt.language, result.Error)
+			}
+			for _, leaked := range []string{"host-secret-71ad", token} {
+				if strings.Contains(result.Error, leaked) || strings.Contains(result.Stdout, leaked) {
+					t.Errorf("compile revealed %q: status %s, error %q, stdout %q", leaked, result.Status, result.Error, result.Stdout)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package executor
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"leetcode-api/internal/infrastructure/sandbox"
+)
+
+// TestMain lets the test binary act as the sandbox helper
+func TestMain(m *testing.M) {
+	sandbox.Init()
+	os.Exit(m.Run())
+}
+
+// shellLanguages defines a runner for POSIX shell scripts, which every test
+// host can run, with the driver of the built-in shell runner
+const shellLanguages = `[{
+	"name": "sh",
+	"displayName": "POSIX shell",
+	"extension": ".sh",
+	"driver": "shell",
+	"run": ["/bin/sh", "{dir}/main.sh"],
+	"version": ["/bin/sh", "-c", "echo sh"]
+}]`
+
+// newShellExecutor returns an unconfined executor whose only language is "sh"
+func newShellExecutor(t *testing.T, workers int) *CodeExecutor {
+	t.Helper()
+	registry, err := ParseRegistry([]byte(shellLanguages))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !registry.Available("sh") {
+		t.Skip("/bin/sh is not available")
+	}
+	return New(Config{Timeout: 5 * time.Second, Workers: workers, Languages: registry}, nil)
+}
+
+// newSandboxedExecutor returns an executor with the built-in languages that
+// compiles and runs submissions confined, skipping where the host cannot
+func newSandboxedExecutor(t *testing.T) *CodeExecutor {
+	t.Helper()
+	sb, err := sandbox.New(sandbox.DefaultIDs())
+	if err != nil {
+		t.Skipf("sandbox unavailable: %v", err)
+	}
+	return New(Config{Timeout: 5 * time.Second}, sb)
+}
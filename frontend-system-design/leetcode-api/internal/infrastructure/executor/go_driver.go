@@ -0,0 +1,294 @@
+// Package executor provides the Go driver.
+package executor
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	problemDomain "leetcode-api/internal/domain/problem"
+)
+
+var goPackageClause = regexp.MustCompile(`(?m)^package\s+\w+`)
+
+// goType maps a signature type to its Go equivalent
+func goType(t problemDomain.ValueType) (string, error) {
+	if t.IsArray() {
+		elem, err := goType(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	}
+
+	switch t {
+	case problemDomain.TypeInteger:
+		return "int", nil
+	case problemDomain.TypeLong:
+		return "int64", nil
+	case problemDomain.TypeDouble:
+		return "float64", nil
+	case problemDomain.TypeBoolean:
+		return "bool", nil
+	case problemDomain.TypeString:
+		return "string", nil
+	case problemDomain.TypeListNode:
+		return "*ListNode", nil
+	case problemDomain.TypeTreeNode:
+		return "*TreeNode", nil
+	}
+	return "", fmt.Errorf("type %s is not supported in Go", t)
+}
+
+// goDecode returns an expression decoding the JSON value raw into type t,
+// which holds nodes; other types are decoded with judgeDecode
+func goDecode(t problemDomain.ValueType, raw string, depth int) (string, error) {
+	switch {
+	case t == problemDomain.TypeListNode:
+		return "judgeList(" + raw + ")", nil
+	case t == problemDomain.TypeTreeNode:
+		return "judgeTree(" + raw + ")", nil
+	}
+
+	typ, err := goType(t)
+	if err != nil {
+		return "", err
+	}
+	item := fmt.Sprintf("item%d", depth)
+	elem, err := goDecode(t.Elem(), item, depth+1)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(`func() %s {
+		var items []json.RawMessage
+		judgeDecode(%s, &items)
+		out := make(%s, len(items))
+		for i, %s := range items {
+			out[i] = %s
+		}
+		return out
+	}()`, typ, raw, typ, item, elem), nil
+}
+
+// goEncode returns an expression converting expr of type t, which holds
+// nodes, to a value that encodes as LeetCode's array forms
+func goEncode(t problemDomain.ValueType, expr string, depth int) string {
+	switch {
+	case t == problemDomain.TypeListNode:
+		return "judgeListValues(" + expr + ")"
+	case t == problemDomain.TypeTreeNode:
+		return "judgeTreeValues(" + expr + ")"
+	}
+
+	item := fmt.Sprintf("item%d", depth)
+	return fmt.Sprintf(`func() []interface{} {
+		out := make([]interface{}, len(%s))
+		for i, %s := range %s {
+			out[i] = %s
+		}
+		return out
+	}()`, expr, item, expr, goEncode(t.Elem(), item, depth+1))
+}
+
+// wrapGo generates a main package that decodes the JSON argument list from
+// stdin, calls the user's function and writes the JSON-encoded result to fd 3.
+// The package declares LeetCode's ListNode and TreeNode for solutions to use.
+func wrapGo(sig problemDomain.Signature) (string, error) {
+	var b strings.Builder
+
+	b.WriteString(`package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+func main() {
+	var args []json.RawMessage
+	if err := json.NewDecoder(os.Stdin).Decode(&args); err != nil {
+		judgeFail("invalid test input: %v", err)
+	}
+`)
+	fmt.Fprintf(&b, "\tif len(args) != %d {\n\t\tjudgeFail(\"expected %d arguments, got %%d\", len(args))\n\t}\n", len(sig.Params), len(sig.Params))
+
+	callArgs := make([]string, len(sig.Params))
+	for i, p := range sig.Params {
+		typ, err := goType(p.Type)
+		if err != nil {
+			return "", fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		callArgs[i] = fmt.Sprintf("arg%d", i)
+		if p.Type.Base().IsNode() {
+			decode, err := goDecode(p.Type, fmt.Sprintf("args[%d]", i), 0)
+			if err != nil {
+				return "", fmt.Errorf("parameter %s: %w", p.Name, err)
+			}
+			fmt.Fprintf(&b, "\targ%d := %s\n", i, decode)
+			continue
+		}
+		fmt.Fprintf(&b, "\tvar arg%d %s\n\tjudgeDecode(args[%d], &arg%d)\n", i, typ, i, i)
+	}
+	call := fmt.Sprintf("%s(%s)", sig.FunctionName, strings.Join(callArgs, ", "))
+
+	if out := sig.Output(); out >= 0 {
+		// The argument the call modified in place is judged
+		judged := fmt.Sprintf("arg%d", out)
+		if sig.Params[out].Type.Base().IsNode() {
+			judged = goEncode(sig.Params[out].Type, judged, 0)
+		}
+		fmt.Fprintf(&b, "\t%s\n\tjudgeWrite(%s)\n}\n", call, judged)
+	} else if sig.ReturnType == problemDomain.TypeVoid {
+		fmt.Fprintf(&b, "\t%s\n\tjudgeWrite(nil)\n}\n", call)
+	} else {
+		if _, err := goType(sig.ReturnType); err != nil {
+			return "", fmt.Errorf("return type: %w", err)
+		}
+		fmt.Fprintf(&b, "\tresult := %s\n", call)
+		switch {
+		case sig.ReturnType.Base().IsNode():
+			fmt.Fprintf(&b, "\tjudgeWrite(%s)\n}\n", goEncode(sig.ReturnType, "result", 0))
+		case sig.ReturnType.IsArray():
+			// A nil slice is the idiomatic empty result but encodes as null
+			b.WriteString("\tif result == nil {\n\t\tjudgeResult.WriteString(\"[]\\n\")\n\t\treturn\n\t}\n")
+			b.WriteString("\tjudgeWrite(result)\n}\n")
+		default:
+			b.WriteString("\tjudgeWrite(result)\n}\n")
+		}
+	}
+
+	b.WriteString(goJudge)
+	b.WriteString(goNodes)
+
+	return b.String(), nil
+}
+
+// wrapGoFunction pairs the generated driver with the user's solution, which
+// lives in its own file so its imports never clash with the driver's
+func wrapGoFunction(code string, sig problemDomain.Signature) (string, string, error) {
+	driver, err := wrapGo(sig)
+	if err != nil {
+		return "", "", err
+	}
+	return driver, goSolution(code), nil
+}
+
+// goSolution moves the user's code into the driver's main package
+func goSolution(code string) string {
+	if goPackageClause.MatchString(code) {
+		return goPackageClause.ReplaceAllString(code, "package main")
+	}
+	return "package main\n\n" + code
+}
+
+// goProgram splits a full Go program into the two files the runner
+// compiles, leaving the second with just its package clause
+func goProgram(code string) (string, string) {
+	return goSolution(code), "package main\n"
+}
+
+// goJudge holds the harness's JSON decoding and result writing
+const goJudge = `
+var judgeResult = os.NewFile(3, "result")
+
+func judgeDecode(raw json.RawMessage, v interface{}) {
+	if err := json.Unmarshal(raw, v); err != nil {
+		judgeFail("invalid test input: %v", err)
+	}
+}
+
+func judgeWrite(v interface{}) {
+	out, err := json.Marshal(v)
+	if err != nil {
+		judgeFail("cannot encode result: %v", err)
+	}
+	judgeResult.Write(append(out, '\n'))
+}
+
+func judgeFail(format string, a ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", a...)
+	os.Exit(2)
+}
+`
+
+// goNodes declares LeetCode's node types and converts them from and to their
+// array forms: a list as its values and a tree in level order, with null for
+// missing children
+const goNodes = `
+type ListNode struct {
+	Val  int
+	Next *ListNode
+}
+
+type TreeNode struct {
+	Val   int
+	Left  *TreeNode
+	Right *TreeNode
+}
+
+func judgeList(raw json.RawMessage) *ListNode {
+	var values []int
+	judgeDecode(raw, &values)
+	var head *ListNode
+	for i := len(values) - 1; i >= 0; i-- {
+		head = &ListNode{Val: values[i], Next: head}
+	}
+	return head
+}
+
+func judgeListValues(head *ListNode) []int {
+	values := []int{}
+	for ; head != nil; head = head.Next {
+		values = append(values, head.Val)
+	}
+	return values
+}
+
+func judgeTree(raw json.RawMessage) *TreeNode {
+	var values []*int
+	judgeDecode(raw, &values)
+	nodes := make([]*TreeNode, len(values))
+	for i, v := range values {
+		if v != nil {
+			nodes[i] = &TreeNode{Val: *v}
+		}
+	}
+	for i, child := 0, 1; i < len(nodes) && child < len(nodes); i++ {
+		if nodes[i] == nil {
+			continue
+		}
+		nodes[i].Left = nodes[child]
+		child++
+		if child < len(nodes) {
+			nodes[i].Right = nodes[child]
+			child++
+		}
+	}
+	if len(nodes) == 0 {
+		return nil
+	}
+	return nodes[0]
+}
+
+func judgeTreeValues(root *TreeNode) []*int {
+	var order []*TreeNode
+	if root != nil {
+		order = append(order, root)
+	}
+	for i := 0; i < len(order); i++ {
+		if order[i] != nil {
+			order = append(order, order[i].Left, order[i].Right)
+		}
+	}
+	for len(order) > 0 && order[len(order)-1] == nil {
+		order = order[:len(order)-1]
+	}
+	values := make([]*int, len(order))
+	for i, node := range order {
+		if node != nil {
+			values[i] = &node.Val
+		}
+	}
+	return values
+}
+`
@@ -0,0 +1,189 @@
+// Package executor provides the judging of interactive problems, whose
+// solutions talk to a judge program while they run.
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	problemDomain "leetcode-api/internal/domain/problem"
+	submissionDomain "leetcode-api/internal/domain/submission"
+	"leetcode-api/internal/infrastructure/sandbox"
+)
+
+// judgeProgram builds the judge program of an interactive problem
+func (e *CodeExecutor) judgeProgram(ctx context.Context, spec problemDomain.InteractiveSpec) (*program, error) {
+	return e.authorProgram(ctx, "judge", spec.Language, spec.Code)
+}
+
+// interactiveChecker accepts every run of an interactive problem that got
+// this far: its judge program rejected the wrong ones while they ran
+func interactiveChecker(ctx context.Context, tc problemDomain.TestCase, output string) (string, bool, error) {
+	return strings.TrimSpace(output), true, nil
+}
+
+// runInteractive runs a prepared solution against its judge program, with
+// each one's stdout connected to the other's stdin. The judge reads the test
+// case from file descriptor 3, so the solution never sees it, and both run
+// in throwaway working directories of their own within the solution's time
+// limit. The outcome describes the solution's run; its result is what the
+// judge printed on stderr. As with runCode, the error is reserved for
+// failures of the judge itself, including a judge program that crashed.
+func (e *CodeExecutor) runInteractive(ctx context.Context, prog *program, tc problemDomain.TestCase) (*runOutcome, error) {
+	ctx, cancel := context.WithTimeout(ctx, prog.timeout)
+	defer cancel()
+
+	solution, err := e.interactiveCommand(ctx, prog)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(solution.Dir)
+	judge, err := e.interactiveCommand(ctx, prog.judge)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(judge.Dir)
+
+	judgeIn, solutionOut, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	solutionIn, judgeOut, err := os.Pipe()
+	if err != nil {
+		closeFiles(judgeIn, solutionOut)
+		return nil, err
+	}
+	payloadIn, payloadOut, err := os.Pipe()
+	if err != nil {
+		closeFiles(judgeIn, solutionOut, solutionIn, judgeOut)
+		return nil, err
+	}
+	// The children hold their own copies, so closing the parent's lets
+	// either side see end of file once the other exits
+	childEnds := []*os.File{judgeIn, solutionOut, solutionIn, judgeOut, payloadIn}
+	defer closeFiles(childEnds...)
+
+	solution.Stdin, solution.Stdout = solutionIn, solutionOut
+	judge.Stdin, judge.Stdout = judgeIn, judgeOut
+	judge.ExtraFiles = []*os.File{payloadIn}
+	solutionStderr := &limitedBuffer{limit: maxStderrSize}
+	judgeStderr := &limitedBuffer{limit: maxStderrSize}
+	solution.Stderr = solutionStderr
+	judge.Stderr = judgeStderr
+
+	// A judge that exits without reading its payload fails the write
+	// instead of blocking the run
+	go func() {
+		json.NewEncoder(payloadOut).Encode(map[string]interface{}{
+			"input":      tc.Input,
+			"expected":   strings.TrimSpace(tc.Expected),
+			"queryLimit": prog.queryLimit,
+		})
+		payloadOut.Close()
+	}()
+
+	if err := judge.Start(); err != nil {
+		payloadOut.Close()
+		return nil, err
+	}
+	if err := solution.Start(); err != nil {
+		cancel()
+		judge.Wait()
+		return nil, err
+	}
+	closeFiles(childEnds...)
+
+	judgeDone := make(chan error, 1)
+	go func() { judgeDone <- judge.Wait() }()
+	watch := watchMemory(solution.Process.Pid, prog.memoryLimit, cancel)
+	solutionErr := solution.Wait()
+	watched, exceeded := watch.stop()
+	judgeErr := <-judgeDone
+
+	run := &runOutcome{
+		result:   strings.TrimSpace(judgeStderr.String()),
+		stderr:   solutionStderr.String(),
+		memory:   max(peakMemory(solution.ProcessState), watched),
+		signal:   exitSignal(solution.ProcessState),
+		exitCode: solution.ProcessState.ExitCode(),
+	}
+	if run.exitCode < 0 {
+		run.exitCode = 0
+	}
+	timedOut := ctx.Err() == context.DeadlineExceeded || run.signal == "SIGXCPU"
+	judgeCode := judge.ProcessState.ExitCode()
+
+	switch {
+	case e.sandbox != nil && e.sandbox.HelperFailed(judge.ProcessState, judgeStderr.String()):
+		return nil, fmt.Errorf("judge failed: %s", run.result)
+	case e.sandbox != nil && e.sandbox.HelperFailed(solution.ProcessState, run.stderr):
+		// The solution never started, which is no fault of the submission
+		run.status = submissionDomain.StatusInternal
+		run.message = strings.TrimSpace(run.stderr)
+	// Stopping the solution for its memory also ends the judge's wait
+	case exceeded:
+		run.status = submissionDomain.StatusMemory
+	// The judge stops waiting for a solution it has rejected, whatever the
+	// solution does next
+	case judgeCode == 1:
+		run.status = submissionDomain.StatusWrong
+		run.message = run.result
+		if run.message == "" {
+			run.message = "the judge rejected the solution"
+		}
+	case timedOut:
+		run.status = submissionDomain.StatusTimeout
+	case judgeErr != nil:
+		var exitErr *exec.ExitError
+		if !errors.As(judgeErr, &exitErr) {
+			return nil, judgeErr
+		}
+		return nil, fmt.Errorf("judge failed: %v %s", judgeErr, run.result)
+	case solutionErr != nil:
+		var exitErr *exec.ExitError
+		if !errors.As(solutionErr, &exitErr) {
+			return nil, solutionErr
+		}
+		run.status = submissionDomain.StatusError
+		run.message = strings.TrimSpace(run.stderr)
+		if run.message == "" {
+			run.message = solutionErr.Error()
+		}
+	}
+	return run, nil
+}
+
+// interactiveCommand sets up a command running prog in a fresh working
+// directory, which the caller removes
+func (e *CodeExecutor) interactiveCommand(ctx context.Context, prog *program) (*exec.Cmd, error) {
+	workDir, err := os.MkdirTemp("", "judge-run-")
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := prog.command(ctx, "")
+	cmd.Dir = workDir
+	// Unconfined children may outlive the killed process and hold its pipes open
+	cmd.WaitDelay = time.Second
+	if e.sandbox != nil {
+		if err := e.sandbox.Wrap(cmd, prog.policy, sandbox.Mount{Path: prog.dir}); err != nil {
+			os.RemoveAll(workDir)
+			return nil, err
+		}
+	}
+	killProcessGroup(cmd)
+	return cmd, nil
+}
+
+// closeFiles closes every file, ignoring the ones already closed
+func closeFiles(files ...*os.File) {
+	for _, f := range files {
+		f.Close()
+	}
+}
@@ -0,0 +1,451 @@
+// Package executor provides the Java driver.
+package executor
+
+import (
+	"fmt"
+	"strings"
+
+	problemDomain "leetcode-api/internal/domain/problem"
+)
+
+// javaType maps a signature type to its Java equivalent
+func javaType(t problemDomain.ValueType) (string, error) {
+	if t.IsArray() {
+		elem, err := javaType(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return elem + "[]", nil
+	}
+
+	switch t {
+	case problemDomain.TypeInteger:
+		return "int", nil
+	case problemDomain.TypeLong:
+		return "long", nil
+	case problemDomain.TypeDouble:
+		return "double", nil
+	case problemDomain.TypeBoolean:
+		return "boolean", nil
+	case problemDomain.TypeString:
+		return "String", nil
+	case problemDomain.TypeCharacter:
+		return "char", nil
+	case problemDomain.TypeListNode:
+		return "ListNode", nil
+	case problemDomain.TypeTreeNode:
+		return "TreeNode", nil
+	}
+	return "", fmt.Errorf("type %s is not supported in Java", t)
+}
+
+// javaConversion returns an expression converting the decoded JSON value
+// expr into type t. depth keeps the lambda parameters of nested arrays apart.
+func javaConversion(t problemDomain.ValueType, expr string, depth int) (string, error) {
+	if t.IsArray() {
+		switch t.Elem() {
+		case problemDomain.TypeInteger:
+			return "Judge.ints(" + expr + ")", nil
+		case problemDomain.TypeLong:
+			return "Judge.longs(" + expr + ")", nil
+		case problemDomain.TypeDouble:
+			return "Judge.doubles(" + expr + ")", nil
+		case problemDomain.TypeBoolean:
+			return "Judge.booleans(" + expr + ")", nil
+		case problemDomain.TypeCharacter:
+			return "Judge.chars(" + expr + ")", nil
+		}
+		elemType, err := javaType(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		param := fmt.Sprintf("v%d", depth)
+		elem, err := javaConversion(t.Elem(), param, depth+1)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Judge.list(%s).stream().map(%s -> %s).toArray(%s[]::new)", expr, param, elem, elemType), nil
+	}
+
+	switch t {
+	case problemDomain.TypeInteger:
+		return "Judge.number(" + expr + ").intValue()", nil
+	case problemDomain.TypeLong:
+		return "Judge.number(" + expr + ").longValue()", nil
+	case problemDomain.TypeDouble:
+		return "Judge.number(" + expr + ").doubleValue()", nil
+	case problemDomain.TypeBoolean:
+		return "(Boolean) " + expr, nil
+	case problemDomain.TypeString:
+		return "(String) " + expr, nil
+	case problemDomain.TypeCharacter:
+		return "Judge.character(" + expr + ")", nil
+	case problemDomain.TypeListNode:
+		return "Judge.listNode(" + expr + ")", nil
+	case problemDomain.TypeTreeNode:
+		return "Judge.treeNode(" + expr + ")", nil
+	}
+	return "", fmt.Errorf("type %s is not supported in Java", t)
+}
+
+// javaEncoding returns an expression converting expr of type t, which holds
+// nodes, to lists that Judge.write encodes as LeetCode's array forms
+func javaEncoding(t problemDomain.ValueType, expr string, depth int) string {
+	switch t {
+	case problemDomain.TypeListNode:
+		return "Judge.values((ListNode) " + expr + ")"
+	case problemDomain.TypeTreeNode:
+		return "Judge.levels((TreeNode) " + expr + ")"
+	}
+	param := fmt.Sprintf("v%d", depth)
+	return fmt.Sprintf("Judge.each(%s, %s -> %s)", expr, param, javaEncoding(t.Elem(), param, depth+1))
+}
+
+// wrapJava generates a Main class that decodes the JSON argument list from
+// stdin, calls the signature's method on the user's Solution class and
+// writes the JSON-encoded result to fd 3. Results are encoded from their
+// runtime type, so solutions may return lists where the signature has arrays,
+// as LeetCode's Java templates do. LeetCode's ListNode and TreeNode are
+// declared alongside Main.
+func wrapJava(code string, sig problemDomain.Signature) (string, string, error) {
+	var args strings.Builder
+	callArgs := make([]string, len(sig.Params))
+	for i, p := range sig.Params {
+		typ, err := javaType(p.Type)
+		if err != nil {
+			return "", "", fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		conversion, err := javaConversion(p.Type, fmt.Sprintf("args.get(%d)", i), 0)
+		if err != nil {
+			return "", "", fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		callArgs[i] = fmt.Sprintf("arg%d", i)
+		fmt.Fprintf(&args, "        %s arg%d = %s;\n", typ, i, conversion)
+	}
+	call := fmt.Sprintf("new Solution().%s(%s)", sig.FunctionName, strings.Join(callArgs, ", "))
+
+	var result string
+	if out := sig.Output(); out >= 0 {
+		// The argument the call modified in place is judged
+		judged := fmt.Sprintf("arg%d", out)
+		if sig.Params[out].Type.Base().IsNode() {
+			judged = javaEncoding(sig.Params[out].Type, judged, 0)
+		}
+		result = fmt.Sprintf("        %s;\n        Object result = %s;\n", call, judged)
+	} else if sig.ReturnType == problemDomain.TypeVoid {
+		result = fmt.Sprintf("        %s;\n        Object result = null;\n", call)
+	} else {
+		if _, err := javaType(sig.ReturnType); err != nil {
+			return "", "", fmt.Errorf("return type: %w", err)
+		}
+		if sig.ReturnType.Base().IsNode() {
+			call = javaEncoding(sig.ReturnType, call, 0)
+		}
+		result = fmt.Sprintf("        Object result = %s;\n", call)
+	}
+
+	harness := `import java.io.*;
+import java.nio.charset.StandardCharsets;
+import java.util.*;
+
+public class Main {
+    public static void main(String[] argv) throws IOException {
+        String input = new String(System.in.readAllBytes(), StandardCharsets.UTF_8);
+        List<Object> args = Judge.list(new Judge(input).parse());
+        if (args.size() != ` + fmt.Sprint(len(sig.Params)) + `) {
+            Judge.fail("expected ` + fmt.Sprint(len(sig.Params)) + ` arguments, got " + args.size());
+        }
+` + args.String() + result + `
+        StringBuilder out = new StringBuilder();
+        Judge.write(out, result);
+        out.append('\n');
+        try (OutputStream stream = new FileOutputStream("/proc/self/fd/3")) {
+            stream.write(out.toString().getBytes(StandardCharsets.UTF_8));
+        }
+    }
+}
+` + javaJudge
+
+	// LeetCode's Java environment imports java.util implicitly
+	return harness, "import java.util.*;\n\n" + code, nil
+}
+
+// javaJudge holds the harness's JSON reader and writer, and LeetCode's node
+// classes. Lists are read and written as their values, trees in level order
+// with null for missing children.
+const javaJudge = `
+class Judge {
+    private final String s;
+    private int pos;
+
+    Judge(String s) {
+        this.s = s;
+    }
+
+    static void fail(String message) {
+        System.err.println("invalid test input: " + message);
+        System.exit(2);
+    }
+
+    Object parse() {
+        Object value = value();
+        ws();
+        if (pos != s.length()) fail("unexpected data after JSON value");
+        return value;
+    }
+
+    private void ws() {
+        while (pos < s.length() && Character.isWhitespace(s.charAt(pos))) pos++;
+    }
+
+    private boolean peek(char c) {
+        ws();
+        return pos < s.length() && s.charAt(pos) == c;
+    }
+
+    private void expect(char c) {
+        if (!peek(c)) fail("expected '" + c + "'");
+        pos++;
+    }
+
+    private Object value() {
+        ws();
+        if (pos >= s.length()) fail("unexpected end of input");
+        char c = s.charAt(pos);
+        if (c == '[') {
+            pos++;
+            List<Object> items = new ArrayList<>();
+            if (peek(']')) {
+                pos++;
+                return items;
+            }
+            do {
+                items.add(value());
+            } while (peek(',') && ++pos > 0);
+            expect(']');
+            return items;
+        }
+        if (c == '"') return string();
+        if (s.startsWith("true", pos)) {
+            pos += 4;
+            return Boolean.TRUE;
+        }
+        if (s.startsWith("false", pos)) {
+            pos += 5;
+            return Boolean.FALSE;
+        }
+        if (s.startsWith("null", pos)) {
+            pos += 4;
+            return null;
+        }
+        int start = pos;
+        while (pos < s.length() && "+-0123456789.eE".indexOf(s.charAt(pos)) >= 0) pos++;
+        if (start == pos) fail("unexpected character '" + c + "'");
+        return new java.math.BigDecimal(s.substring(start, pos));
+    }
+
+    private String string() {
+        expect('"');
+        StringBuilder b = new StringBuilder();
+        while (pos < s.length() && s.charAt(pos) != '"') {
+            char c = s.charAt(pos++);
+            if (c != '\\' || pos >= s.length()) {
+                b.append(c);
+                continue;
+            }
+            char e = s.charAt(pos++);
+            switch (e) {
+                case 'n': b.append('\n'); break;
+                case 't': b.append('\t'); break;
+                case 'r': b.append('\r'); break;
+                case 'b': b.append('\b'); break;
+                case 'f': b.append('\f'); break;
+                case 'u':
+                    b.append((char) Integer.parseInt(s.substring(pos, pos + 4), 16));
+                    pos += 4;
+                    break;
+                default: b.append(e);
+            }
+        }
+        expect('"');
+        return b.toString();
+    }
+
+    @SuppressWarnings("unchecked")
+    static List<Object> list(Object v) {
+        if (!(v instanceof List)) fail("expected an array");
+        return (List<Object>) v;
+    }
+
+    static List<Object> args(Object v, int n) {
+        List<Object> items = list(v);
+        if (items.size() != n) fail("expected " + n + " arguments, got " + items.size());
+        return items;
+    }
+
+    static Number number(Object v) {
+        if (!(v instanceof Number)) fail("expected a number");
+        return (Number) v;
+    }
+
+    static char character(Object v) {
+        if (!(v instanceof String) || ((String) v).length() != 1) fail("expected a single character");
+        return ((String) v).charAt(0);
+    }
+
+    static int[] ints(Object v) {
+        return list(v).stream().mapToInt(x -> number(x).intValue()).toArray();
+    }
+
+    static long[] longs(Object v) {
+        return list(v).stream().mapToLong(x -> number(x).longValue()).toArray();
+    }
+
+    static double[] doubles(Object v) {
+        return list(v).stream().mapToDouble(x -> number(x).doubleValue()).toArray();
+    }
+
+    static boolean[] booleans(Object v) {
+        List<Object> items = list(v);
+        boolean[] out = new boolean[items.size()];
+        for (int i = 0; i < out.length; i++) out[i] = (Boolean) items.get(i);
+        return out;
+    }
+
+    static char[] chars(Object v) {
+        List<Object> items = list(v);
+        char[] out = new char[items.size()];
+        for (int i = 0; i < out.length; i++) out[i] = character(items.get(i));
+        return out;
+    }
+
+    static ListNode listNode(Object v) {
+        int[] values = ints(v);
+        ListNode head = null;
+        for (int i = values.length - 1; i >= 0; i--) head = new ListNode(values[i], head);
+        return head;
+    }
+
+    static TreeNode treeNode(Object v) {
+        List<Object> values = list(v);
+        TreeNode[] nodes = new TreeNode[values.size()];
+        for (int i = 0; i < nodes.length; i++) {
+            if (values.get(i) != null) nodes[i] = new TreeNode(number(values.get(i)).intValue());
+        }
+        for (int i = 0, child = 1; i < nodes.length && child < nodes.length; i++) {
+            if (nodes[i] == null) continue;
+            nodes[i].left = nodes[child++];
+            if (child < nodes.length) nodes[i].right = nodes[child++];
+        }
+        return nodes.length == 0 ? null : nodes[0];
+    }
+
+    static List<Integer> values(ListNode head) {
+        List<Integer> values = new ArrayList<>();
+        for (ListNode node = head; node != null; node = node.next) values.add(node.val);
+        return values;
+    }
+
+    static List<Integer> levels(TreeNode root) {
+        List<TreeNode> order = new ArrayList<>();
+        if (root != null) order.add(root);
+        for (int i = 0; i < order.size(); i++) {
+            TreeNode node = order.get(i);
+            if (node != null) {
+                order.add(node.left);
+                order.add(node.right);
+            }
+        }
+        while (!order.isEmpty() && order.get(order.size() - 1) == null) order.remove(order.size() - 1);
+        List<Integer> values = new ArrayList<>();
+        for (TreeNode node : order) values.add(node == null ? null : node.val);
+        return values;
+    }
+
+    static List<Object> each(Object v, java.util.function.Function<Object, Object> f) {
+        List<Object> out = new ArrayList<>();
+        if (v instanceof Iterable) {
+            for (Object item : (Iterable<?>) v) out.add(f.apply(item));
+        } else if (v != null) {
+            int n = java.lang.reflect.Array.getLength(v);
+            for (int i = 0; i < n; i++) out.add(f.apply(java.lang.reflect.Array.get(v, i)));
+        }
+        return out;
+    }
+
+    static void write(StringBuilder out, Object v) {
+        if (v == null) {
+            out.append("null");
+        } else if (v instanceof String || v instanceof Character) {
+            out.append('"');
+            for (char c : v.toString().toCharArray()) {
+                if (c == '"' || c == '\\') out.append('\\').append(c);
+                else if (c == '\n') out.append("\\n");
+                else if (c == '\t') out.append("\\t");
+                else if (c == '\r') out.append("\\r");
+                else if (c < 0x20) out.append(String.format("\\u%04x", (int) c));
+                else out.append(c);
+            }
+            out.append('"');
+        } else if (v instanceof Boolean || v instanceof Integer || v instanceof Long
+                || v instanceof Short || v instanceof Byte) {
+            out.append(v);
+        } else if (v instanceof Number) {
+            out.append(((Number) v).doubleValue());
+        } else if (v instanceof Iterable) {
+            out.append('[');
+            boolean first = true;
+            for (Object item : (Iterable<?>) v) {
+                if (!first) out.append(',');
+                first = false;
+                write(out, item);
+            }
+            out.append(']');
+        } else if (v.getClass().isArray()) {
+            out.append('[');
+            int n = java.lang.reflect.Array.getLength(v);
+            for (int i = 0; i < n; i++) {
+                if (i > 0) out.append(',');
+                write(out, java.lang.reflect.Array.get(v, i));
+            }
+            out.append(']');
+        } else {
+            out.append('"').append(v).append('"');
+        }
+    }
+}
+
+class ListNode {
+    int val;
+    ListNode next;
+
+    ListNode() {}
+
+    ListNode(int val) {
+        this.val = val;
+    }
+
+    ListNode(int val, ListNode next) {
+        this.val = val;
+        this.next = next;
+    }
+}
+
+class TreeNode {
+    int val;
+    TreeNode left;
+    TreeNode right;
+
+    TreeNode() {}
+
+    TreeNode(int val) {
+        this.val = val;
+    }
+
+    TreeNode(int val, TreeNode left, TreeNode right) {
+        this.val = val;
+        this.left = left;
+        this.right = right;
+    }
+}
+`
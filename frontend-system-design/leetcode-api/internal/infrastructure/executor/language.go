@@ -0,0 +1,267 @@
+// Package executor provides the language runner registry.
+package executor
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	submissionDomain "leetcode-api/internal/domain/submission"
+	"leetcode-api/internal/infrastructure/sandbox"
+)
+
+// versionProbeTimeout bounds each runner's version probe
+const versionProbeTimeout = 10 * time.Second
+
+//go:embed languages.json
+var defaultLanguages []byte
+
+// LanguageRunner builds and runs submissions written in one language.
+// The executor writes a program's sources, generated by the runner's driver
+// template, into a directory, compiles them there once and then runs the
+// result for every test case. Driver templates are built into the executor,
+// so a runner definition can add another runtime or version of a supported
+// language family, such as a newer Python or Node.js, while a language
+// with a harness of its own needs a new template in driverTemplates.
+type LanguageRunner interface {
+	// Name is the identifier submissions use, such as "python"
+	Name() string
+	// DisplayName is the human-readable name, such as "Python 3"
+	DisplayName() string
+	// FileExtension is the extension of the runner's source files, such as ".py"
+	FileExtension() string
+	// Driver names the driver template that wraps solutions into a harness
+	Driver() string
+	// CompileCommand returns the command that builds the sources in dir,
+	// or nil for interpreted languages. cache is the runner's build cache
+	// directory, empty unless BuildCache asks for one.
+	CompileCommand(ctx context.Context, dir, cache string) *exec.Cmd
+	// BuildCache reports whether compiles share a persistent cache directory
+	BuildCache() bool
+	// Command returns the command that runs the program built in dir
+	Command(ctx context.Context, dir string) *exec.Cmd
+	// Version probes the toolchain, failing when it is not installed
+	Version(ctx context.Context) (string, error)
+	// Policy declares the sandbox resources the language runtime needs
+	Policy() sandbox.Policy
+	// TimeMultiplier scales the time limit of each run, as runtimes differ
+	// in speed and startup cost; 0 leaves it unchanged
+	TimeMultiplier() float64
+}
+
+// CompileError reports a submission that failed to build.
+// Its message is the compiler's diagnostic output.
+type CompileError struct {
+	Output string
+}
+
+func (e *CompileError) Error() string { return e.Output }
+
+// RunnerConfig defines a language runner in the registry's config file.
+// Driver must name one of the built-in driverTemplates. Each submission gets
+// a scratch directory holding the driver and solution, which commands and
+// environment entries may refer to as {dir}. Drivers write the harness to
+// main<extension> there, and drivers that keep the solution separate write
+// it to solution<extension>; the Java driver uses Main.java and
+// Solution.java instead, as its file names follow class names. Compile
+// settings may also refer to the runner's build cache as {cache}.
+type RunnerConfig struct {
+	Name           string         `json:"name"`
+	DisplayName    string         `json:"displayName"`
+	Extension      string         `json:"extension"`
+	Driver         string         `json:"driver"`
+	Compile        []string       `json:"compile,omitempty"`    // run once in {dir}; omitted for interpreted languages
+	CompileEnv     []string       `json:"compileEnv,omitempty"` // added to the confined environment while compiling
+	BuildCache     bool           `json:"buildCache,omitempty"` // compiles share a cache directory, {cache} in compile settings
+	Run            []string       `json:"run,omitempty"`
+	RunEnv         []string       `json:"runEnv,omitempty"` // replaces the default environment of runs when set
+	Version        []string       `json:"version,omitempty"`
+	Policy         sandbox.Policy `json:"policy"`
+	TimeMultiplier float64        `json:"timeMultiplier,omitempty"` // scales the time limit per test case
+}
+
+// Validate checks that the definition is complete
+func (c RunnerConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("runner has no name")
+	}
+	if _, ok := driverTemplates[c.Driver]; !ok {
+		return fmt.Errorf("runner %s: unknown driver %q, want one of %s", c.Name, c.Driver, strings.Join(driverNames(), ", "))
+	}
+	if len(c.Run) == 0 && c.Driver != sqlDriver {
+		return fmt.Errorf("runner %s: no run command", c.Name)
+	}
+	if c.TimeMultiplier < 0 {
+		return fmt.Errorf("runner %s: negative time multiplier %g", c.Name, c.TimeMultiplier)
+	}
+	return nil
+}
+
+// commandRunner is a LanguageRunner defined by a RunnerConfig
+type commandRunner struct {
+	config RunnerConfig
+}
+
+// NewCommandRunner returns a runner that compiles and runs programs with the configured commands
+func NewCommandRunner(config RunnerConfig) (LanguageRunner, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &commandRunner{config: config}, nil
+}
+
+func (r *commandRunner) Name() string            { return r.config.Name }
+func (r *commandRunner) DisplayName() string     { return r.config.DisplayName }
+func (r *commandRunner) FileExtension() string   { return r.config.Extension }
+func (r *commandRunner) Driver() string          { return r.config.Driver }
+func (r *commandRunner) Policy() sandbox.Policy  { return r.config.Policy }
+func (r *commandRunner) TimeMultiplier() float64 { return r.config.TimeMultiplier }
+
+func (r *commandRunner) BuildCache() bool { return r.config.BuildCache }
+
+func (r *commandRunner) CompileCommand(ctx context.Context, dir, cache string) *exec.Cmd {
+	if len(r.config.Compile) == 0 {
+		return nil
+	}
+	expand := func(args []string) []string {
+		expanded := expandDir(args, dir)
+		for i, arg := range expanded {
+			expanded[i] = strings.ReplaceAll(arg, "{cache}", cache)
+		}
+		return expanded
+	}
+
+	argv := expand(r.config.Compile)
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	// Compilers read untrusted sources, so they see no more of the server's
+	// environment than the programs they build
+	cmd.Env = append(sandbox.Environ(), expand(r.config.CompileEnv)...)
+	return cmd
+}
+
+func (r *commandRunner) Command(ctx context.Context, dir string) *exec.Cmd {
+	argv := expandDir(r.config.Run, dir)
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	if len(r.config.RunEnv) > 0 {
+		cmd.Env = expandDir(r.config.RunEnv, dir)
+	}
+	return cmd
+}
+
+func (r *commandRunner) Version(ctx context.Context) (string, error) {
+	if len(r.config.Version) == 0 {
+		return "", nil
+	}
+	output, err := exec.CommandContext(ctx, r.config.Version[0], r.config.Version[1:]...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", r.config.Version[0], err)
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+	return line, nil
+}
+
+// expandDir substitutes the program directory into configured arguments
+func expandDir(args []string, dir string) []string {
+	expanded := make([]string, len(args))
+	for i, arg := range args {
+		expanded[i] = strings.ReplaceAll(arg, "{dir}", dir)
+	}
+	return expanded
+}
+
+// Registry holds the language runners submissions can use,
+// together with the toolchain versions probed when it was created
+type Registry struct {
+	runners  []LanguageRunner
+	versions map[string]string
+	errors   map[string]error // probe failures of runners whose toolchain is missing
+}
+
+// NewRegistry registers runners and probes their toolchains
+func NewRegistry(runners ...LanguageRunner) (*Registry, error) {
+	r := &Registry{versions: make(map[string]string), errors: make(map[string]error)}
+	for _, runner := range runners {
+		if _, ok := r.Runner(runner.Name()); ok {
+			return nil, fmt.Errorf("language %s is registered twice", runner.Name())
+		}
+		r.runners = append(r.runners, runner)
+
+		ctx, cancel := context.WithTimeout(context.Background(), versionProbeTimeout)
+		version, err := runner.Version(ctx)
+		cancel()
+		if err != nil {
+			r.errors[runner.Name()] = err
+		}
+		r.versions[runner.Name()] = version
+	}
+	return r, nil
+}
+
+// ParseRegistry builds a registry from a JSON array of runner definitions
+func ParseRegistry(data []byte) (*Registry, error) {
+	var configs []RunnerConfig
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("invalid language config: %w", err)
+	}
+
+	runners := make([]LanguageRunner, len(configs))
+	for i, config := range configs {
+		runner, err := NewCommandRunner(config)
+		if err != nil {
+			return nil, err
+		}
+		runners[i] = runner
+	}
+	return NewRegistry(runners...)
+}
+
+// LoadRegistry builds a registry from a config file
+func LoadRegistry(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegistry(data)
+}
+
+// DefaultRegistry builds the registry of built-in language definitions
+func DefaultRegistry() (*Registry, error) {
+	return ParseRegistry(defaultLanguages)
+}
+
+// Runner returns the runner registered for a language
+func (r *Registry) Runner(name string) (LanguageRunner, bool) {
+	for _, runner := range r.runners {
+		if runner.Name() == name {
+			return runner, true
+		}
+	}
+	return nil, false
+}
+
+// Available reports whether a language is registered and its toolchain was found
+func (r *Registry) Available(name string) bool {
+	_, ok := r.Runner(name)
+	return ok && r.errors[name] == nil
+}
+
+// Languages describes the registered runners in registration order
+func (r *Registry) Languages() []submissionDomain.Language {
+	languages := make([]submissionDomain.Language, len(r.runners))
+	for i, runner := range r.runners {
+		languages[i] = submissionDomain.Language{
+			ID:             runner.Name(),
+			Name:           runner.DisplayName(),
+			Extension:      runner.FileExtension(),
+			Version:        r.versions[runner.Name()],
+			Available:      r.errors[runner.Name()] == nil,
+			TimeMultiplier: runner.TimeMultiplier(),
+		}
+	}
+	return languages
+}
@@ -0,0 +1,117 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunnerConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  RunnerConfig
+		wantErr bool
+	}{
+		{"interpreted", RunnerConfig{Name: "python", Driver: "python", Run: []string{"python3", "{dir}/main.py"}}, false},
+		{"compiled", RunnerConfig{Name: "cpp", Driver: "cpp", Compile: []string{"g++", "main.cpp"}, Run: []string{"{dir}/solution"}, TimeMultiplier: 1.5}, false},
+		{"sql needs no command", RunnerConfig{Name: "sql", Driver: sqlDriver}, false},
+		{"no name", RunnerConfig{Driver: "python", Run: []string{"python3"}}, true},
+		{"unknown driver", RunnerConfig{Name: "ts", Driver: "typescript", Run: []string{"node"}}, true},
+		{"no driver", RunnerConfig{Name: "python", Run: []string{"python3"}}, true},
+		{"no run command", RunnerConfig{Name: "python", Driver: "python"}, true},
+		{"negative time multiplier", RunnerConfig{Name: "python", Driver: "python", Run: []string{"python3"}, TimeMultiplier: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadRegistry(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    string
+		wantErr   bool
+		available map[string]bool
+	}{
+		{
+			name: "probes toolchains",
+			config: `[
+				{"name": "sh", "driver": "shell", "run": ["/bin/sh"], "version": ["/bin/sh", "-c", "echo 1.0"]},
+				{"name": "missing", "driver": "python", "run": ["python3"], "version": ["/nonexistent/python3"]}
+			]`,
+			available: map[string]bool{"sh": true, "missing": false, "python": false},
+		},
+		{name: "invalid JSON", config: `{"name": "sh"}`, wantErr: true},
+		{name: "invalid runner", config: `[{"name": "sh", "driver": "shell"}]`, wantErr: true},
+		{
+			name:    "duplicate runner",
+			config:  `[{"name": "sh", "driver": "shell", "run": ["/bin/sh"]}, {"name": "sh", "driver": "shell", "run": ["/bin/bash"]}]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "languages.json")
+			if err := os.WriteFile(path, []byte(tt.config), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			registry, err := LoadRegistry(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadRegistry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for language, want := range tt.available {
+				if got := registry.Available(language); got != want {
+					t.Errorf("Available(%s) = %v, want %v", language, got, want)
+				}
+			}
+		})
+	}
+
+	if _, err := LoadRegistry(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadRegistry() of a missing file succeeded")
+	}
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	registry, err := DefaultRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, language := range []string{"javascript", "python", "go", "cpp", "java", "rust", "bash", "sql"} {
+		if _, ok := registry.Runner(language); !ok {
+			t.Errorf("built-in language %s is not registered", language)
+		}
+	}
+}
+
+func TestCompileCommandEnv(t *testing.T) {
+	t.Setenv("JUDGE_TOKEN", "compile-secret")
+	registry, err := DefaultRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, language := range []string{"go", "cpp", "java", "rust"} {
+		runner, ok := registry.Runner(language)
+		if !ok {
+			t.Fatalf("built-in language %s is not registered", language)
+		}
+		cmd := runner.CompileCommand(context.Background(), t.TempDir(), t.TempDir())
+		if cmd == nil {
+			t.Errorf("%s has no compile command", language)
+			continue
+		}
+		for _, v := range cmd.Env {
+			if strings.Contains(v, "compile-secret") {
+				t.Errorf("%s compiles with the server's %s", language, v)
+			}
+		}
+	}
+}
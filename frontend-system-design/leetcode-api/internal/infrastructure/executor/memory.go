@@ -0,0 +1,56 @@
+// Package executor provides the watching of running programs' memory, so
+// that programs going over their limit are stopped instead of timing out.
+package executor
+
+import "time"
+
+// memoryPollInterval is how often a running program's memory is measured
+const memoryPollInterval = 10 * time.Millisecond
+
+// memoryWatch measures the resident memory of a running process group
+type memoryWatch struct {
+	done     chan struct{}
+	finished chan struct{}
+	peak     int // highest resident memory seen, in KB
+	exceeded bool
+}
+
+// watchMemory polls the resident memory of the process group led by pid and
+// calls kill once it goes over limit KB. A zero limit watches nothing.
+func watchMemory(pid, limit int, kill func()) *memoryWatch {
+	w := &memoryWatch{done: make(chan struct{}), finished: make(chan struct{})}
+	if limit <= 0 {
+		close(w.finished)
+		return w
+	}
+
+	go func() {
+		defer close(w.finished)
+		ticker := time.NewTicker(memoryPollInterval)
+		defer ticker.Stop()
+		for {
+			if used := groupMemory(pid); used > w.peak {
+				w.peak = used
+			}
+			if w.peak > limit {
+				w.exceeded = true
+				kill()
+				return
+			}
+			select {
+			case <-w.done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return w
+}
+
+// stop ends the watch of an exited process and returns the peak memory seen
+// in KB and whether it went over the limit
+func (w *memoryWatch) stop() (peak int, exceeded bool) {
+	close(w.done)
+	<-w.finished
+	return w.peak, w.exceeded
+}
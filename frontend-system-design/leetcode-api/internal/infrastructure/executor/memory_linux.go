@@ -0,0 +1,48 @@
+//go:build linux
+
+// Package executor provides the measuring of process group memory from /proc.
+package executor
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// groupMemory returns the resident memory in KB used by the processes in the
+// process group pgid
+func groupMemory(pgid int) int {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return 0
+	}
+	group := strconv.Itoa(pgid)
+	pageKB := os.Getpagesize() / 1024
+
+	total := 0
+	for _, entry := range entries {
+		name := entry.Name()
+		if name[0] < '0' || name[0] > '9' {
+			continue
+		}
+		stat, err := os.ReadFile("/proc/" + name + "/stat")
+		if err != nil {
+			// The process exited since the directory was read
+			continue
+		}
+		// The command name may contain spaces, so fields are counted from
+		// its closing parenthesis: the group is the 5th field, rss the 24th
+		end := bytes.LastIndexByte(stat, ')')
+		if end < 0 {
+			continue
+		}
+		fields := strings.Fields(string(stat[end+1:]))
+		if len(fields) < 22 || fields[2] != group {
+			continue
+		}
+		pages, _ := strconv.Atoi(fields[21])
+		total += pages * pageKB
+	}
+	return total
+}
@@ -0,0 +1,23 @@
+//go:build !unix
+
+// Package executor provides stubs for platforms without rusage or signals.
+package executor
+
+import (
+	"os"
+	"os/exec"
+)
+
+// killProcessGroup leaves cmd's default cancellation, which kills only the
+// command itself
+func killProcessGroup(cmd *exec.Cmd) {}
+
+// peakMemory is not measured on this platform
+func peakMemory(state *os.ProcessState) int {
+	return 0
+}
+
+// exitSignal is not reported on this platform
+func exitSignal(state *os.ProcessState) string {
+	return ""
+}
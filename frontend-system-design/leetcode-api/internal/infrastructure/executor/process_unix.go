@@ -0,0 +1,61 @@
+//go:build unix
+
+// Package executor provides resource and exit status inspection for finished processes.
+package executor
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// peakMemory returns the peak resident set size in KB of a finished process
+// and the descendants it waited for
+func peakMemory(state *os.ProcessState) int {
+	if state == nil {
+		return 0
+	}
+	usage, ok := state.SysUsage().(*syscall.Rusage)
+	if !ok {
+		return 0
+	}
+	// Darwin reports ru_maxrss in bytes, other systems in kilobytes
+	if runtime.GOOS == "darwin" || runtime.GOOS == "ios" {
+		return int(usage.Maxrss / 1024)
+	}
+	return int(usage.Maxrss)
+}
+
+// killProcessGroup starts cmd in a process group of its own and makes
+// cancelling its context kill the whole group, so that processes the
+// command started do not outlive it. It must be called after the sandbox
+// has set up the command.
+func killProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+	cmd.Cancel = func() error {
+		// A negative pid signals every process in the group
+		if err := syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL); err != nil {
+			return cmd.Process.Kill()
+		}
+		return nil
+	}
+}
+
+// exitSignal returns the name of the signal that killed a finished process,
+// such as SIGSEGV, or "" if it exited on its own
+func exitSignal(state *os.ProcessState) string {
+	if state == nil {
+		return ""
+	}
+	status, ok := state.Sys().(syscall.WaitStatus)
+	if !ok || !status.Signaled() {
+		return ""
+	}
+	return unix.SignalName(status.Signal())
+}
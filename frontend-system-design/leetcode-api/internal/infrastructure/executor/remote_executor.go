@@ -0,0 +1,365 @@
+// Package executor provides the client that judges code on remote servers.
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	problemDomain "leetcode-api/internal/domain/problem"
+	submissionDomain "leetcode-api/internal/domain/submission"
+)
+
+// RemoteConfig holds the remote executor's settings. Zero values select the defaults.
+type RemoteConfig struct {
+	URLs           []string      // base URLs of the judge servers
+	Token          string        // bearer token the servers require, if any
+	Timeout        time.Duration // limit on judging one submission, including the transfer
+	Retries        int           // further attempts after a request fails
+	RetryBackoff   time.Duration // pause before the first retry, doubled for each one after it
+	HealthInterval time.Duration // how often MonitorHealth probes the servers
+	HealthTimeout  time.Duration // limit on one health check
+}
+
+// Default settings used for zero RemoteConfig values
+const (
+	defaultRemoteTimeout  = 2 * time.Minute
+	defaultRemoteRetries  = 2
+	defaultRetryBackoff   = 250 * time.Millisecond
+	defaultHealthInterval = 10 * time.Second
+	defaultHealthTimeout  = 3 * time.Second
+)
+
+// RemoteExecutor judges code on Server instances over HTTP. Requests go to
+// the servers in turn, skipping those that failed their last health check;
+// failed requests are retried on the next server. When every attempt fails
+// the tests are reported as internal errors.
+type RemoteExecutor struct {
+	endpoints      []*remoteEndpoint
+	token          string
+	client         *http.Client
+	timeout        time.Duration
+	retries        int
+	retryBackoff   time.Duration
+	healthInterval time.Duration
+	healthTimeout  time.Duration
+	next           atomic.Uint64 // round-robin position
+
+	mu        sync.Mutex
+	languages []submissionDomain.Language // from the last successful health check
+}
+
+// remoteEndpoint is one judge server
+type remoteEndpoint struct {
+	url     string
+	healthy atomic.Bool
+}
+
+// rejectedError is a request the server refused, which retrying cannot fix
+type rejectedError struct {
+	err error
+}
+
+func (e *rejectedError) Error() string { return e.err.Error() }
+
+// NewRemote creates a RemoteExecutor for the servers at cfg.URLs
+func NewRemote(cfg RemoteConfig) (*RemoteExecutor, error) {
+	if len(cfg.URLs) == 0 {
+		return nil, errors.New("remote executor needs at least one server URL")
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultRemoteTimeout
+	}
+	if cfg.Retries <= 0 {
+		cfg.Retries = defaultRemoteRetries
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaultRetryBackoff
+	}
+	if cfg.HealthInterval <= 0 {
+		cfg.HealthInterval = defaultHealthInterval
+	}
+	if cfg.HealthTimeout <= 0 {
+		cfg.HealthTimeout = defaultHealthTimeout
+	}
+
+	e := &RemoteExecutor{
+		token:          cfg.Token,
+		client:         &http.Client{},
+		timeout:        cfg.Timeout,
+		retries:        cfg.Retries,
+		retryBackoff:   cfg.RetryBackoff,
+		healthInterval: cfg.HealthInterval,
+		healthTimeout:  cfg.HealthTimeout,
+	}
+	for _, raw := range cfg.URLs {
+		u, err := url.Parse(strings.TrimSpace(raw))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid judge server URL %q", raw)
+		}
+		endpoint := &remoteEndpoint{url: strings.TrimRight(u.String(), "/")}
+		// Servers are presumed healthy until a check says otherwise
+		endpoint.healthy.Store(true)
+		e.endpoints = append(e.endpoints, endpoint)
+	}
+	return e, nil
+}
+
+// Execute sends code and test cases to a judge server and returns the
+// results it reports. Cancelling ctx abandons the request, which stops the
+// run on the server, and reports the tests as cancelled. onResult, when not
+// nil, receives each test's result as the server streams it; a request
+// retried after a partial reply may report a test twice.
+func (e *RemoteExecutor) Execute(ctx context.Context, problem *problemDomain.Problem, language, code string, testCases []problemDomain.TestCase, onResult submissionDomain.ResultFunc) []submissionDomain.TestResult {
+	body, err := json.Marshal(executeRequest{
+		Problem:   toProblemPayload(problem),
+		Language:  language,
+		Code:      code,
+		TestCases: toTestCasePayloads(testCases),
+	})
+	if err != nil {
+		return failedResults(testCases, submissionDomain.StatusInternal, err.Error(), onResult)
+	}
+
+	for attempt := 0; ; attempt++ {
+		endpoint := e.pick()
+		results, err := e.executeOn(ctx, endpoint, body, len(testCases), onResult)
+		if err == nil {
+			return results
+		}
+		if ctx.Err() != nil {
+			return failedResults(testCases, submissionDomain.StatusCancelled, "judging was cancelled", onResult)
+		}
+
+		var rejected *rejectedError
+		if errors.As(err, &rejected) || attempt == e.retries {
+			log.Printf("remote executor: %s: %v", endpoint.url, err)
+			return failedResults(testCases, submissionDomain.StatusInternal, "judge server: "+err.Error(), onResult)
+		}
+		log.Printf("remote executor: %s: %v, retrying", endpoint.url, err)
+		endpoint.healthy.Store(false)
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(e.retryBackoff << attempt):
+		}
+	}
+}
+
+// executeOn judges a submission on one server
+func (e *RemoteExecutor) executeOn(ctx context.Context, endpoint *remoteEndpoint, body []byte, tests int, onResult submissionDomain.ResultFunc) ([]submissionDomain.TestResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	resp, err := e.do(ctx, http.MethodPost, endpoint.url+executePath, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err := responseError(resp)
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+			return nil, &rejectedError{err}
+		}
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var message executeMessage
+		if err := decoder.Decode(&message); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, fmt.Errorf("reading results: %w", err)
+		}
+
+		switch {
+		case message.Error != "":
+			return nil, errors.New(message.Error)
+
+		case message.Done:
+			if len(message.Results) != tests {
+				return nil, fmt.Errorf("server returned %d results for %d tests", len(message.Results), tests)
+			}
+			results := make([]submissionDomain.TestResult, tests)
+			for i, payload := range message.Results {
+				results[i] = toDomainResult(payload)
+			}
+			return results, nil
+
+		case message.Index != nil && message.Result != nil:
+			if *message.Index >= 0 && *message.Index < tests && onResult != nil {
+				onResult(*message.Index, toDomainResult(*message.Result))
+			}
+		}
+	}
+}
+
+// pick returns the next server in turn, preferring healthy ones
+func (e *RemoteExecutor) pick() *remoteEndpoint {
+	n := uint64(len(e.endpoints))
+	start := e.next.Add(1)
+	for i := uint64(0); i < n; i++ {
+		if endpoint := e.endpoints[(start+i)%n]; endpoint.healthy.Load() {
+			return endpoint
+		}
+	}
+	// With no server known to be healthy, try them anyway
+	return e.endpoints[start%n]
+}
+
+// do sends an authenticated request
+func (e *RemoteExecutor) do(ctx context.Context, method, url string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if e.token != "" {
+		req.Header.Set("Authorization", "Bearer "+e.token)
+	}
+	return e.client.Do(req)
+}
+
+// CheckHealth probes every server, updating which ones requests go to, and
+// refreshes the languages from the first healthy server. It fails when no
+// server is healthy.
+func (e *RemoteExecutor) CheckHealth(ctx context.Context) error {
+	var languages []submissionDomain.Language
+	var errs []error
+	for _, endpoint := range e.endpoints {
+		reported, err := e.checkEndpoint(ctx, endpoint)
+		if err != nil {
+			if endpoint.healthy.Swap(false) {
+				log.Printf("remote executor: %s is unhealthy: %v", endpoint.url, err)
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", endpoint.url, err))
+			continue
+		}
+		if !endpoint.healthy.Swap(true) {
+			log.Printf("remote executor: %s is healthy again", endpoint.url)
+		}
+		if languages == nil {
+			languages = reported
+		}
+	}
+
+	if languages == nil {
+		return fmt.Errorf("no healthy judge server: %w", errors.Join(errs...))
+	}
+	e.mu.Lock()
+	e.languages = languages
+	e.mu.Unlock()
+	return nil
+}
+
+// checkEndpoint runs a health check against one server
+func (e *RemoteExecutor) checkEndpoint(ctx context.Context, endpoint *remoteEndpoint) ([]submissionDomain.Language, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.healthTimeout)
+	defer cancel()
+
+	resp, err := e.do(ctx, http.MethodGet, endpoint.url+healthPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp)
+	}
+
+	var health healthResponse
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+		return nil, fmt.Errorf("decoding health: %w", err)
+	}
+	if health.Status != "ok" {
+		return nil, fmt.Errorf("server reports status %q", health.Status)
+	}
+	languages := make([]submissionDomain.Language, len(health.Languages))
+	for i, l := range health.Languages {
+		languages[i] = toDomainLanguage(l)
+	}
+	return languages, nil
+}
+
+// MonitorHealth checks the servers' health periodically until ctx is done
+func (e *RemoteExecutor) MonitorHealth(ctx context.Context) {
+	ticker := time.NewTicker(e.healthInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := e.CheckHealth(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("remote executor: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Supports reports whether the judge servers can run a language
+func (e *RemoteExecutor) Supports(language string) bool {
+	for _, l := range e.Languages() {
+		if l.ID == language {
+			return l.Available
+		}
+	}
+	return false
+}
+
+// Languages describes the languages of the judge servers, as reported by
+// the last successful health check
+func (e *RemoteExecutor) Languages() []submissionDomain.Language {
+	e.mu.Lock()
+	languages := e.languages
+	e.mu.Unlock()
+
+	if languages == nil {
+		// No server has answered yet; ask now rather than reject every language
+		if err := e.CheckHealth(context.Background()); err != nil {
+			return nil
+		}
+		e.mu.Lock()
+		languages = e.languages
+		e.mu.Unlock()
+	}
+	return append([]submissionDomain.Language(nil), languages...)
+}
+
+// responseError describes a failed reply from its JSON error, or its status
+func responseError(resp *http.Response) error {
+	var body errorResponse
+	if json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&body) == nil && body.Error != "" {
+		return fmt.Errorf("%s: %s", resp.Status, body.Error)
+	}
+	return errors.New(resp.Status)
+}
+
+// failedResults reports every test with a status saying why none was judged
+func failedResults(testCases []problemDomain.TestCase, status submissionDomain.Status, message string, onResult submissionDomain.ResultFunc) []submissionDomain.TestResult {
+	results := make([]submissionDomain.TestResult, len(testCases))
+	for i, tc := range testCases {
+		results[i] = newTestResult(tc)
+		results[i].Status = status
+		results[i].Error = message
+		if onResult != nil {
+			onResult(i, results[i])
+		}
+	}
+	return results
+}
@@ -0,0 +1,164 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	problemDomain "leetcode-api/internal/domain/problem"
+	submissionDomain "leetcode-api/internal/domain/submission"
+)
+
+// newTestRemote serves local over HTTP and returns a client for it
+func newTestRemote(t *testing.T, local *CodeExecutor, serverToken, clientToken string) *RemoteExecutor {
+	t.Helper()
+	server := httptest.NewServer(NewServer(local, serverToken))
+	t.Cleanup(server.Close)
+
+	remote, err := NewRemote(RemoteConfig{URLs: []string{server.URL}, Token: clientToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return remote
+}
+
+func TestRemoteExecuteRoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		problem   *problemDomain.Problem
+		code      string
+		testCases []problemDomain.TestCase
+	}{
+		{
+			name:    "verdicts",
+			problem: &problemDomain.Problem{Slug: "echo", IOMode: true},
+			code:    echoScript,
+			testCases: []problemDomain.TestCase{
+				{Input: "0", Expected: "0"},
+				{Input: "1", Expected: "2", IsHidden: true},
+				{Input: "fail", Expected: "fail"},
+				{Input: "0", Expected: "0"},
+			},
+		},
+		{
+			name:      "output and fixtures",
+			problem:   &problemDomain.Problem{Slug: "files", IOMode: true},
+			code:      "echo debug >&2\ncat words.txt",
+			testCases: []problemDomain.TestCase{{Input: "", Expected: "a b", Files: map[string]string{"words.txt": "a b\n"}}},
+		},
+		{
+			name: "checker program",
+			problem: &problemDomain.Problem{Slug: "checked", IOMode: true, Checker: &problemDomain.CheckerSpec{
+				Kind: problemDomain.CheckerProgram, Language: "sh", Code: `grep -q '"actual":"yes"'`,
+			}},
+			code:      "read answer\necho \"$answer\"",
+			testCases: []problemDomain.TestCase{{Input: "yes", Expected: "any"}, {Input: "no", Expected: "any"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			local := newShellExecutor(t, 2)
+			remote := newTestRemote(t, local, "secret", "secret")
+			ctx := context.Background()
+
+			want := local.Execute(ctx, tt.problem, "sh", tt.code, tt.testCases, nil)
+			var mu sync.Mutex
+			streamed := make(map[int]bool)
+			got := remote.Execute(ctx, tt.problem, "sh", tt.code, tt.testCases, func(i int, result submissionDomain.TestResult) {
+				mu.Lock()
+				defer mu.Unlock()
+				streamed[i] = true
+			})
+
+			if len(got) != len(want) {
+				t.Fatalf("got %d results, want %d", len(got), len(want))
+			}
+			for i := range want {
+				// Timings differ between runs
+				got[i].Runtime, got[i].Memory = 0, 0
+				want[i].Runtime, want[i].Memory = 0, 0
+				if !reflect.DeepEqual(got[i], want[i]) {
+					t.Errorf("result %d = %+v, want %+v", i, got[i], want[i])
+				}
+			}
+			if len(streamed) != len(tt.testCases) {
+				t.Errorf("streamed %d results, want %d", len(streamed), len(tt.testCases))
+			}
+		})
+	}
+}
+
+func TestRemoteExecuteRejected(t *testing.T) {
+	tests := []struct {
+		name        string
+		clientToken string
+		language    string
+		wantError   string
+	}{
+		{"wrong token", "guess", "sh", "401"},
+		{"missing token", "", "sh", "401"},
+		{"missing language", "secret", "", "language is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remote := newTestRemote(t, newShellExecutor(t, 1), "secret", tt.clientToken)
+			problem := &problemDomain.Problem{Slug: "echo", IOMode: true}
+			testCases := []problemDomain.TestCase{{Input: "0", Expected: "0"}}
+
+			for i, result := range remote.Execute(context.Background(), problem, tt.language, echoScript, testCases, nil) {
+				if result.Status != submissionDomain.StatusInternal || !strings.Contains(result.Error, tt.wantError) {
+					t.Errorf("result %d = %s %q, want %s containing %q", i, result.Status, result.Error, submissionDomain.StatusInternal, tt.wantError)
+				}
+			}
+		})
+	}
+}
+
+func TestRemoteHealth(t *testing.T) {
+	remote := newTestRemote(t, newShellExecutor(t, 1), "secret", "secret")
+	if err := remote.CheckHealth(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !remote.Supports("sh") {
+		t.Error("Supports(sh) = false, want true")
+	}
+	if remote.Supports("python") {
+		t.Error("Supports(python) = true for a server without it")
+	}
+
+	unauthorized := newTestRemote(t, newShellExecutor(t, 1), "secret", "guess")
+	if err := unauthorized.CheckHealth(context.Background()); err == nil {
+		t.Error("CheckHealth() with a wrong token succeeded")
+	}
+}
+
+func TestProblemPayloadRoundTrip(t *testing.T) {
+	problem := &problemDomain.Problem{
+		ID:       7,
+		Slug:     "two-sum",
+		Category: problemDomain.CategoryAlgorithms,
+		Signature: &problemDomain.Signature{
+			FunctionName: "twoSum",
+			Params:       []problemDomain.Param{{Name: "nums", Type: "integer[]"}, {Name: "target", Type: problemDomain.TypeInteger}},
+			ReturnType:   "integer[]",
+		},
+		Checker:     &problemDomain.CheckerSpec{Kind: problemDomain.CheckerUnorderedList},
+		MemoryLimit: 64,
+	}
+
+	encoded, err := json.Marshal(toProblemPayload(problem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var payload problemPayload
+	if err := json.Unmarshal(encoded, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if got := toDomainProblem(payload); !reflect.DeepEqual(got, problem) {
+		t.Errorf("round trip = %+v, want %+v", got, problem)
+	}
+}
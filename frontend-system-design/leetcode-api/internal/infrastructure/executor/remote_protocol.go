@@ -0,0 +1,211 @@
+// Package executor provides the wire format shared by the remote executor and its server.
+package executor
+
+import (
+	problemDomain "leetcode-api/internal/domain/problem"
+	submissionDomain "leetcode-api/internal/domain/submission"
+)
+
+// Paths served by Server
+const (
+	healthPath  = "/health"
+	executePath = "/execute"
+)
+
+// maxRequestSize bounds the body of an execute request
+const maxRequestSize = 32 << 20
+
+// healthResponse is the body of a health check
+type healthResponse struct {
+	Status    string            `json:"status"`
+	Languages []languagePayload `json:"languages"`
+}
+
+// languagePayload describes a language the server can run
+type languagePayload struct {
+	ID             string  `json:"id"`
+	Name           string  `json:"name"`
+	Extension      string  `json:"extension"`
+	Version        string  `json:"version,omitempty"`
+	Available      bool    `json:"available"`
+	TimeMultiplier float64 `json:"timeMultiplier,omitempty"`
+}
+
+// executeRequest asks the server to judge code against test cases
+type executeRequest struct {
+	Problem   problemPayload    `json:"problem"`
+	Language  string            `json:"language"`
+	Code      string            `json:"code"`
+	TestCases []testCasePayload `json:"testCases"`
+}
+
+// problemPayload carries the parts of a problem the executor judges with
+type problemPayload struct {
+	ID          uint                           `json:"id"`
+	Slug        string                         `json:"slug"`
+	Category    problemDomain.Category         `json:"category"`
+	Signature   *problemDomain.Signature       `json:"signature,omitempty"`
+	Database    *problemDomain.DatabaseSpec    `json:"database,omitempty"`
+	Concurrency *problemDomain.ConcurrencySpec `json:"concurrency,omitempty"`
+	Design      *problemDomain.DesignSpec      `json:"design,omitempty"`
+	Interactive *problemDomain.InteractiveSpec `json:"interactive,omitempty"`
+	IOMode      bool                           `json:"ioMode,omitempty"`
+	Checker     *problemDomain.CheckerSpec     `json:"checker,omitempty"`
+	MemoryLimit int                            `json:"memoryLimit,omitempty"`
+}
+
+// testCasePayload is a test case to run
+type testCasePayload struct {
+	Input    string            `json:"input"`
+	Expected string            `json:"expected"`
+	Files    map[string]string `json:"files,omitempty"`
+	IsHidden bool              `json:"isHidden,omitempty"`
+}
+
+// executeMessage is one line of the server's newline-delimited JSON reply:
+// the result of a test as soon as it finishes, and last, all results.
+// A server that fails after replying sends Error instead.
+type executeMessage struct {
+	Index   *int            `json:"index,omitempty"`
+	Result  *resultPayload  `json:"result,omitempty"`
+	Results []resultPayload `json:"results,omitempty"`
+	Done    bool            `json:"done,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// resultPayload is the result of one test
+type resultPayload struct {
+	Input    string                  `json:"input"`
+	Expected string                  `json:"expected"`
+	Actual   string                  `json:"actual"`
+	Passed   bool                    `json:"passed"`
+	Status   submissionDomain.Status `json:"status"`
+	Error    string                  `json:"error,omitempty"`
+	Signal   string                  `json:"signal,omitempty"`
+	ExitCode int                     `json:"exitCode,omitempty"`
+	Stdout   string                  `json:"stdout,omitempty"`
+	Stderr   string                  `json:"stderr,omitempty"`
+	Runtime  int                     `json:"runtime"`
+	Memory   int                     `json:"memory"`
+}
+
+// errorResponse is the body of a rejected request
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// --- Mappers ---
+
+func toLanguagePayload(l submissionDomain.Language) languagePayload {
+	return languagePayload{
+		ID:             l.ID,
+		Name:           l.Name,
+		Extension:      l.Extension,
+		Version:        l.Version,
+		Available:      l.Available,
+		TimeMultiplier: l.TimeMultiplier,
+	}
+}
+
+func toDomainLanguage(p languagePayload) submissionDomain.Language {
+	return submissionDomain.Language{
+		ID:             p.ID,
+		Name:           p.Name,
+		Extension:      p.Extension,
+		Version:        p.Version,
+		Available:      p.Available,
+		TimeMultiplier: p.TimeMultiplier,
+	}
+}
+
+func toProblemPayload(p *problemDomain.Problem) problemPayload {
+	return problemPayload{
+		ID:          p.ID,
+		Slug:        p.Slug,
+		Category:    p.Category,
+		Signature:   p.Signature,
+		Database:    p.Database,
+		Concurrency: p.Concurrency,
+		Design:      p.Design,
+		Interactive: p.Interactive,
+		IOMode:      p.IOMode,
+		Checker:     p.Checker,
+		MemoryLimit: p.MemoryLimit,
+	}
+}
+
+func toDomainProblem(p problemPayload) *problemDomain.Problem {
+	return &problemDomain.Problem{
+		ID:          p.ID,
+		Slug:        p.Slug,
+		Category:    p.Category,
+		Signature:   p.Signature,
+		Database:    p.Database,
+		Concurrency: p.Concurrency,
+		Design:      p.Design,
+		Interactive: p.Interactive,
+		IOMode:      p.IOMode,
+		Checker:     p.Checker,
+		MemoryLimit: p.MemoryLimit,
+	}
+}
+
+func toTestCasePayloads(testCases []problemDomain.TestCase) []testCasePayload {
+	payloads := make([]testCasePayload, len(testCases))
+	for i, tc := range testCases {
+		payloads[i] = testCasePayload{
+			Input:    tc.Input,
+			Expected: tc.Expected,
+			Files:    tc.Files,
+			IsHidden: tc.IsHidden,
+		}
+	}
+	return payloads
+}
+
+func toDomainTestCases(payloads []testCasePayload) []problemDomain.TestCase {
+	testCases := make([]problemDomain.TestCase, len(payloads))
+	for i, p := range payloads {
+		testCases[i] = problemDomain.TestCase{
+			Input:    p.Input,
+			Expected: p.Expected,
+			Files:    p.Files,
+			IsHidden: p.IsHidden,
+		}
+	}
+	return testCases
+}
+
+func toResultPayload(r submissionDomain.TestResult) resultPayload {
+	return resultPayload{
+		Input:    r.Input,
+		Expected: r.Expected,
+		Actual:   r.Actual,
+		Passed:   r.Passed,
+		Status:   r.Status,
+		Error:    r.Error,
+		Signal:   r.Signal,
+		ExitCode: r.ExitCode,
+		Stdout:   r.Stdout,
+		Stderr:   r.Stderr,
+		Runtime:  r.Runtime,
+		Memory:   r.Memory,
+	}
+}
+
+func toDomainResult(p resultPayload) submissionDomain.TestResult {
+	return submissionDomain.TestResult{
+		Input:    p.Input,
+		Expected: p.Expected,
+		Actual:   p.Actual,
+		Passed:   p.Passed,
+		Status:   p.Status,
+		Error:    p.Error,
+		Signal:   p.Signal,
+		ExitCode: p.ExitCode,
+		Stdout:   p.Stdout,
+		Stderr:   p.Stderr,
+		Runtime:  p.Runtime,
+		Memory:   p.Memory,
+	}
+}
@@ -0,0 +1,493 @@
+// Package executor provides the Rust driver.
+package executor
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	problemDomain "leetcode-api/internal/domain/problem"
+)
+
+// rustType maps a signature type to its Rust equivalent
+func rustType(t problemDomain.ValueType) (string, error) {
+	if t.IsArray() {
+		elem, err := rustType(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "Vec<" + elem + ">", nil
+	}
+
+	switch t {
+	case problemDomain.TypeInteger:
+		return "i32", nil
+	case problemDomain.TypeLong:
+		return "i64", nil
+	case problemDomain.TypeDouble:
+		return "f64", nil
+	case problemDomain.TypeBoolean:
+		return "bool", nil
+	case problemDomain.TypeString:
+		return "String", nil
+	case problemDomain.TypeCharacter:
+		return "char", nil
+	case problemDomain.TypeListNode:
+		return "Option<Box<ListNode>>", nil
+	case problemDomain.TypeTreeNode:
+		// Spelled out, since the solution may import Rc and RefCell itself
+		return "Option<std::rc::Rc<std::cell::RefCell<TreeNode>>>", nil
+	}
+	return "", fmt.Errorf("type %s is not supported in Rust", t)
+}
+
+// rustName converts a signature's camelCase function name to the snake_case
+// name LeetCode's Rust templates use, such as twoSum to two_sum
+func rustName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// wrapRust generates a harness that declares LeetCode's Solution struct and
+// node types, includes the user's impl block, decodes the JSON argument list from stdin
+// and writes the JSON-encoded result of the associated function to fd 3
+func wrapRust(code string, sig problemDomain.Signature) (string, string, error) {
+	var args strings.Builder
+	callArgs := make([]string, len(sig.Params))
+	for i, p := range sig.Params {
+		typ, err := rustType(p.Type)
+		if err != nil {
+			return "", "", fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		if i > 0 {
+			args.WriteString("    parser.expect(b',');\n")
+		}
+		if i == sig.Output() {
+			// LeetCode's templates take the argument judged after the call by mutable reference
+			callArgs[i] = fmt.Sprintf("&mut arg%d", i)
+			fmt.Fprintf(&args, "    let mut arg%d: %s = parser.read();\n", i, typ)
+			continue
+		}
+		callArgs[i] = fmt.Sprintf("arg%d", i)
+		fmt.Fprintf(&args, "    let arg%d: %s = parser.read();\n", i, typ)
+	}
+	call := fmt.Sprintf("Solution::%s(%s)", rustName(sig.FunctionName), strings.Join(callArgs, ", "))
+
+	var emit string
+	if out := sig.Output(); out >= 0 {
+		emit = fmt.Sprintf("    %s;\n    let mut out = String::new();\n    judge::ToJson::to_json(&arg%d, &mut out);\n", call, out)
+	} else if sig.ReturnType == problemDomain.TypeVoid {
+		emit = fmt.Sprintf("    %s;\n    let mut out = String::from(\"null\");\n", call)
+	} else {
+		if _, err := rustType(sig.ReturnType); err != nil {
+			return "", "", fmt.Errorf("return type: %w", err)
+		}
+		emit = fmt.Sprintf("    let result = %s;\n    let mut out = String::new();\n    judge::ToJson::to_json(&result, &mut out);\n", call)
+	}
+
+	return `#![allow(dead_code, unused_imports, unused_mut, non_snake_case)]
+
+pub struct Solution;
+` + rustNodes + `
+include!("solution.rs");
+
+fn main() {
+    let mut input = String::new();
+    if let Err(err) = std::io::Read::read_to_string(&mut std::io::stdin(), &mut input) {
+        judge::fail(&err.to_string());
+    }
+    let mut parser = judge::Parser::new(&input);
+    parser.expect(b'[');
+` + args.String() + `    parser.expect(b']');
+
+` + emit + `    out.push('\n');
+    judge::emit(&out);
+}
+` + rustJudge, code, nil
+}
+
+// rustNodes declares LeetCode's node types
+const rustNodes = `
+#[derive(PartialEq, Eq, Clone, Debug)]
+pub struct ListNode {
+    pub val: i32,
+    pub next: Option<Box<ListNode>>,
+}
+
+impl ListNode {
+    #[inline]
+    fn new(val: i32) -> Self {
+        ListNode { next: None, val }
+    }
+}
+
+#[derive(Debug, PartialEq, Eq)]
+pub struct TreeNode {
+    pub val: i32,
+    pub left: Option<std::rc::Rc<std::cell::RefCell<TreeNode>>>,
+    pub right: Option<std::rc::Rc<std::cell::RefCell<TreeNode>>>,
+}
+
+impl TreeNode {
+    #[inline]
+    pub fn new(val: i32) -> Self {
+        TreeNode { val, left: None, right: None }
+    }
+}
+`
+
+// rustJudge holds the harness's JSON reader and writer. Lists are read and
+// written as their values, trees in level order with null for missing
+// children.
+const rustJudge = `
+mod judge {
+    use std::io::Write;
+
+    pub fn fail(what: &str) -> ! {
+        eprintln!("invalid test input: {}", what);
+        std::process::exit(2)
+    }
+
+    pub struct Parser<'a> {
+        s: &'a [u8],
+        pos: usize,
+    }
+
+    impl<'a> Parser<'a> {
+        pub fn new(s: &'a str) -> Self {
+            Parser { s: s.as_bytes(), pos: 0 }
+        }
+
+        fn ws(&mut self) {
+            while self.pos < self.s.len() && self.s[self.pos].is_ascii_whitespace() {
+                self.pos += 1;
+            }
+        }
+
+        pub fn peek(&mut self, c: u8) -> bool {
+            self.ws();
+            self.pos < self.s.len() && self.s[self.pos] == c
+        }
+
+        pub fn expect(&mut self, c: u8) {
+            if !self.peek(c) {
+                fail(&format!("expected '{}'", c as char));
+            }
+            self.pos += 1;
+        }
+
+        fn literal(&mut self, word: &str) -> bool {
+            self.ws();
+            if self.s[self.pos..].starts_with(word.as_bytes()) {
+                self.pos += word.len();
+                return true;
+            }
+            false
+        }
+
+        fn number(&mut self) -> &'a str {
+            self.ws();
+            let start = self.pos;
+            while self.pos < self.s.len() && b"+-0123456789.eE".contains(&self.s[self.pos]) {
+                self.pos += 1;
+            }
+            if start == self.pos {
+                fail("expected a number");
+            }
+            std::str::from_utf8(&self.s[start..self.pos]).unwrap()
+        }
+
+        fn string(&mut self) -> String {
+            self.expect(b'"');
+            let mut bytes = Vec::new();
+            while self.pos < self.s.len() && self.s[self.pos] != b'"' {
+                let c = self.s[self.pos];
+                self.pos += 1;
+                if c != b'\\' || self.pos >= self.s.len() {
+                    bytes.push(c);
+                    continue;
+                }
+                let e = self.s[self.pos];
+                self.pos += 1;
+                match e {
+                    b'n' => bytes.push(b'\n'),
+                    b't' => bytes.push(b'\t'),
+                    b'r' => bytes.push(b'\r'),
+                    b'b' => bytes.push(8),
+                    b'f' => bytes.push(12),
+                    b'u' => {
+                        let end = (self.pos + 4).min(self.s.len());
+                        let hex = std::str::from_utf8(&self.s[self.pos..end]).unwrap_or("");
+                        let c = u32::from_str_radix(hex, 16).ok().and_then(char::from_u32).unwrap_or('\u{fffd}');
+                        self.pos = end;
+                        let mut buf = [0u8; 4];
+                        bytes.extend_from_slice(c.encode_utf8(&mut buf).as_bytes());
+                    }
+                    other => bytes.push(other),
+                }
+            }
+            self.expect(b'"');
+            String::from_utf8(bytes).unwrap_or_else(|_| fail("invalid UTF-8 in string"))
+        }
+
+        pub fn read<T: FromJson>(&mut self) -> T {
+            T::from_json(self)
+        }
+    }
+
+    pub trait FromJson: Sized {
+        fn from_json(p: &mut Parser) -> Self;
+    }
+
+    impl FromJson for i32 {
+        fn from_json(p: &mut Parser) -> Self {
+            p.number().parse().unwrap_or_else(|_| fail("expected a 32-bit integer"))
+        }
+    }
+
+    impl FromJson for i64 {
+        fn from_json(p: &mut Parser) -> Self {
+            p.number().parse().unwrap_or_else(|_| fail("expected a 64-bit integer"))
+        }
+    }
+
+    impl FromJson for f64 {
+        fn from_json(p: &mut Parser) -> Self {
+            p.number().parse().unwrap_or_else(|_| fail("expected a number"))
+        }
+    }
+
+    impl FromJson for bool {
+        fn from_json(p: &mut Parser) -> Self {
+            if p.literal("true") {
+                true
+            } else if p.literal("false") {
+                false
+            } else {
+                fail("expected a boolean")
+            }
+        }
+    }
+
+    impl FromJson for String {
+        fn from_json(p: &mut Parser) -> Self {
+            p.string()
+        }
+    }
+
+    impl FromJson for char {
+        fn from_json(p: &mut Parser) -> Self {
+            let s = p.string();
+            let mut chars = s.chars();
+            match (chars.next(), chars.next()) {
+                (Some(c), None) => c,
+                _ => fail("expected a single character"),
+            }
+        }
+    }
+
+    impl<T: FromJson> FromJson for Vec<T> {
+        fn from_json(p: &mut Parser) -> Self {
+            p.expect(b'[');
+            let mut items = Vec::new();
+            if p.peek(b']') {
+                p.pos += 1;
+                return items;
+            }
+            loop {
+                items.push(T::from_json(p));
+                if !p.peek(b',') {
+                    break;
+                }
+                p.pos += 1;
+            }
+            p.expect(b']');
+            items
+        }
+    }
+
+    type Tree = Option<std::rc::Rc<std::cell::RefCell<super::TreeNode>>>;
+
+    impl FromJson for Option<Box<super::ListNode>> {
+        fn from_json(p: &mut Parser) -> Self {
+            let values: Vec<i32> = p.read();
+            let mut head = None;
+            for val in values.into_iter().rev() {
+                head = Some(Box::new(super::ListNode { val, next: head }));
+            }
+            head
+        }
+    }
+
+    impl FromJson for Tree {
+        fn from_json(p: &mut Parser) -> Self {
+            p.expect(b'[');
+            let mut nodes: Vec<Tree> = Vec::new();
+            if p.peek(b']') {
+                p.pos += 1;
+            } else {
+                loop {
+                    if p.literal("null") {
+                        nodes.push(None);
+                    } else {
+                        let node = super::TreeNode::new(i32::from_json(p));
+                        nodes.push(Some(std::rc::Rc::new(std::cell::RefCell::new(node))));
+                    }
+                    if !p.peek(b',') {
+                        break;
+                    }
+                    p.pos += 1;
+                }
+                p.expect(b']');
+            }
+            let mut child = 1;
+            for node in nodes.iter() {
+                if child >= nodes.len() {
+                    break;
+                }
+                if let Some(node) = node {
+                    let mut node = node.borrow_mut();
+                    node.left = nodes[child].clone();
+                    node.right = nodes.get(child + 1).cloned().flatten();
+                    child += 2;
+                }
+            }
+            nodes.into_iter().next().flatten()
+        }
+    }
+
+    pub trait ToJson {
+        fn to_json(&self, out: &mut String);
+    }
+
+    macro_rules! display_json {
+        ($($t:ty),*) => {
+            $(impl ToJson for $t {
+                fn to_json(&self, out: &mut String) {
+                    out.push_str(&self.to_string());
+                }
+            })*
+        };
+    }
+
+    display_json!(i8, i16, i32, i64, i128, isize, u8, u16, u32, u64, u128, usize, f32, f64, bool);
+
+    impl ToJson for str {
+        fn to_json(&self, out: &mut String) {
+            out.push('"');
+            for c in self.chars() {
+                match c {
+                    '"' => out.push_str("\\\""),
+                    '\\' => out.push_str("\\\\"),
+                    '\n' => out.push_str("\\n"),
+                    '\t' => out.push_str("\\t"),
+                    '\r' => out.push_str("\\r"),
+                    c if (c as u32) < 0x20 => out.push_str(&format!("\\u{:04x}", c as u32)),
+                    c => out.push(c),
+                }
+            }
+            out.push('"');
+        }
+    }
+
+    impl ToJson for String {
+        fn to_json(&self, out: &mut String) {
+            self.as_str().to_json(out);
+        }
+    }
+
+    impl ToJson for char {
+        fn to_json(&self, out: &mut String) {
+            self.to_string().to_json(out);
+        }
+    }
+
+    impl<T: ToJson> ToJson for Option<T> {
+        fn to_json(&self, out: &mut String) {
+            match self {
+                Some(v) => v.to_json(out),
+                None => out.push_str("null"),
+            }
+        }
+    }
+
+    impl ToJson for Option<Box<super::ListNode>> {
+        fn to_json(&self, out: &mut String) {
+            out.push('[');
+            let mut node = self;
+            while let Some(current) = node {
+                if !std::ptr::eq(node, self) {
+                    out.push(',');
+                }
+                current.val.to_json(out);
+                node = &current.next;
+            }
+            out.push(']');
+        }
+    }
+
+    impl ToJson for Tree {
+        fn to_json(&self, out: &mut String) {
+            let mut order: Vec<Tree> = Vec::new();
+            if self.is_some() {
+                order.push(self.clone());
+            }
+            let mut i = 0;
+            while i < order.len() {
+                if let Some(node) = order[i].clone() {
+                    let node = node.borrow();
+                    order.push(node.left.clone());
+                    order.push(node.right.clone());
+                }
+                i += 1;
+            }
+            while let Some(None) = order.last() {
+                order.pop();
+            }
+            out.push('[');
+            for (i, node) in order.iter().enumerate() {
+                if i > 0 {
+                    out.push(',');
+                }
+                match node {
+                    Some(node) => node.borrow().val.to_json(out),
+                    None => out.push_str("null"),
+                }
+            }
+            out.push(']');
+        }
+    }
+
+    impl<T: ToJson> ToJson for Vec<T> {
+        fn to_json(&self, out: &mut String) {
+            out.push('[');
+            for (i, item) in self.iter().enumerate() {
+                if i > 0 {
+                    out.push(',');
+                }
+                item.to_json(out);
+            }
+            out.push(']');
+        }
+    }
+
+    pub fn emit(out: &str) {
+        use std::os::unix::io::FromRawFd;
+        // The judge opens fd 3 for the result before starting the program
+        let mut result = unsafe { std::fs::File::from_raw_fd(3) };
+        if result.write_all(out.as_bytes()).is_err() {
+            eprintln!("cannot write result");
+            std::process::exit(2);
+        }
+    }
+}
+`
@@ -0,0 +1,127 @@
+// Package executor provides the HTTP server that judges code for remote executors.
+package executor
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	submissionDomain "leetcode-api/internal/domain/submission"
+)
+
+// Server exposes a CodeExecutor over HTTP, so that submissions can be judged
+// on other hosts than the API's. RemoteExecutor is its client.
+//
+//	GET  /health   reports the server's languages
+//	POST /execute  judges code against test cases, replying with a
+//	               newline-delimited JSON message per finished test
+//	               and a last message holding all results
+type Server struct {
+	executor *CodeExecutor
+	token    string // required as a bearer token when set
+	mux      *http.ServeMux
+}
+
+// NewServer creates a Server around an executor. A non-empty token must be
+// sent by clients as a bearer token.
+func NewServer(executor *CodeExecutor, token string) *Server {
+	s := &Server{executor: executor, token: token, mux: http.NewServeMux()}
+	s.mux.HandleFunc(healthPath, s.health)
+	s.mux.HandleFunc(executePath, s.execute)
+	return s
+}
+
+// ServeHTTP authenticates the request and routes it
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "missing or invalid token")
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// authorized checks the request's bearer token
+func (s *Server) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+// health reports that the server is up and which languages it runs
+func (s *Server) health(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	languages := s.executor.Languages()
+	response := healthResponse{Status: "ok", Languages: make([]languagePayload, len(languages))}
+	for i, l := range languages {
+		response.Languages[i] = toLanguagePayload(l)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// execute judges a submission, streaming each test's result as it finishes
+func (s *Server) execute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req executeRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, "request too large")
+			return
+		}
+		writeError(w, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+	if req.Language == "" {
+		writeError(w, http.StatusBadRequest, "language is required")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	var mu sync.Mutex
+	send := func(message executeMessage) {
+		mu.Lock()
+		defer mu.Unlock()
+		// Writes fail once the client has gone away, which also cancels the run
+		if encoder.Encode(message) == nil && flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	results := s.executor.Execute(
+		r.Context(), toDomainProblem(req.Problem), req.Language, req.Code, toDomainTestCases(req.TestCases),
+		func(index int, result submissionDomain.TestResult) {
+			payload := toResultPayload(result)
+			send(executeMessage{Index: &index, Result: &payload})
+		},
+	)
+
+	final := executeMessage{Done: true, Results: make([]resultPayload, len(results))}
+	for i, result := range results {
+		final.Results[i] = toResultPayload(result)
+	}
+	send(final)
+}
+
+// writeError replies with a JSON error
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{Error: message})
+}
@@ -0,0 +1,34 @@
+// Package executor provides the bash runner for shell problems.
+package executor
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// shellTools are the only commands reachable through a script's PATH
+var shellTools = []string{
+	"awk", "cat", "cut", "echo", "grep", "head", "paste", "printf", "rev",
+	"sed", "seq", "sort", "tac", "tail", "tee", "tr", "uniq", "wc", "xargs",
+}
+
+// stageShellTools creates the bin directory of allowlisted tools next to a
+// script. Runners put only that directory on the script's PATH.
+func stageShellTools(dir string) error {
+	binDir := filepath.Join(dir, "bin")
+	if err := os.Mkdir(binDir, 0o755); err != nil {
+		return err
+	}
+	for _, tool := range shellTools {
+		path, err := exec.LookPath(tool)
+		if err != nil {
+			// Tools missing on this host are simply unavailable to scripts
+			continue
+		}
+		if err := os.Symlink(path, filepath.Join(binDir, tool)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
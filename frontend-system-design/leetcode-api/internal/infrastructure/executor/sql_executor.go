@@ -0,0 +1,309 @@
+// Package executor provides SQL judging against in-memory SQLite databases.
+package executor
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+
+	problemDomain "leetcode-api/internal/domain/problem"
+	submissionDomain "leetcode-api/internal/domain/submission"
+)
+
+// sqlDriverName is a SQLite driver that cannot attach database files,
+// so user queries stay inside their in-memory database
+const sqlDriverName = "sqlite3_judge"
+
+const (
+	// sqlValueLimit is the largest string or blob a query may build
+	sqlValueLimit = 16 << 20
+	// sqlHeapLimit caps SQLite's memory in the whole process. SQLite has no
+	// per-connection limit, so it is shared with the server's own database
+	// and only stops queries that would take the server down.
+	sqlHeapLimit = 512 << 20
+)
+
+func init() {
+	sql.Register(sqlDriverName, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			conn.SetLimit(sqlite3.SQLITE_LIMIT_ATTACHED, 0)
+			conn.SetLimit(sqlite3.SQLITE_LIMIT_LENGTH, sqlValueLimit)
+			if _, err := conn.Exec(fmt.Sprintf("PRAGMA hard_heap_limit = %d", sqlHeapLimit), nil); err != nil {
+				return err
+			}
+			// Queries may not move the process-wide limits the server depends on
+			conn.RegisterAuthorizer(func(action int, arg1, _, _ string) int {
+				if action == sqlite3.SQLITE_PRAGMA && strings.HasSuffix(strings.ToLower(arg1), "heap_limit") {
+					return sqlite3.SQLITE_DENY
+				}
+				return sqlite3.SQLITE_OK
+			})
+			return nil
+		},
+	})
+}
+
+// Query failures that end in a verdict other than Runtime Error
+var (
+	errSQLTimeout = errors.New("query exceeded the time limit")
+	errSQLMemory  = errors.New("query exceeded the memory limit")
+	errSQLOutput  = errors.New("query result exceeded the output limit")
+)
+
+// sqlFixture is a test case input in LeetCode's database format
+type sqlFixture struct {
+	Headers map[string][]string        `json:"headers"`
+	Rows    map[string][][]interface{} `json:"rows"`
+}
+
+// sqlResult is a query result in LeetCode's database format
+type sqlResult struct {
+	Headers []string        `json:"headers"`
+	Values  [][]interface{} `json:"values"`
+}
+
+// executeSQL runs the user's query against a fresh database per test case.
+// Queries run inside the server, one worker slot each like programs.
+func (e *CodeExecutor) executeSQL(ctx context.Context, problem *problemDomain.Problem, query string, testCases []problemDomain.TestCase) []submissionDomain.TestResult {
+	results := make([]submissionDomain.TestResult, len(testCases))
+
+	for i, tc := range testCases {
+		if ctx.Err() != nil {
+			results[i] = cancelledResult(tc)
+			continue
+		}
+
+		expected := strings.TrimSpace(tc.Expected)
+		results[i] = submissionDomain.TestResult{
+			Input:    tc.Input,
+			Expected: expected,
+		}
+
+		if problem.Database == nil {
+			results[i].Status = submissionDomain.StatusInternal
+			results[i].Error = fmt.Sprintf("problem %s has no database schema", problem.Slug)
+			continue
+		}
+
+		select {
+		case e.workers <- struct{}{}:
+		case <-ctx.Done():
+			results[i] = cancelledResult(tc)
+			continue
+		}
+		start := time.Now()
+		actual, err := e.runSQL(ctx, problem.Database.Schema, tc.Input, query)
+		results[i].Runtime = int(time.Since(start).Milliseconds())
+		<-e.workers
+
+		if err != nil && ctx.Err() != nil {
+			results[i] = cancelledResult(tc)
+			continue
+		}
+		if err != nil {
+			switch {
+			case errors.Is(err, errSQLTimeout):
+				results[i].Status = submissionDomain.StatusTimeout
+			case errors.Is(err, errSQLMemory):
+				results[i].Status = submissionDomain.StatusMemory
+			case errors.Is(err, errSQLOutput):
+				results[i].Status = submissionDomain.StatusOutput
+			default:
+				results[i].Status = submissionDomain.StatusError
+			}
+			results[i].Error = err.Error()
+			continue
+		}
+
+		output, _ := json.Marshal(actual)
+		results[i].Actual = string(output)
+		results[i].Passed = sqlResultsEqual(actual, expected, problem.Database.Ordered)
+		results[i].Status = submissionDomain.StatusWrong
+		if results[i].Passed {
+			results[i].Status = submissionDomain.StatusAccepted
+		}
+	}
+
+	return results
+}
+
+// runSQL loads the schema and fixture rows into an in-memory database and runs the query
+func (e *CodeExecutor) runSQL(ctx context.Context, schema, input, query string) (*sqlResult, error) {
+	var fixture sqlFixture
+	decoder := json.NewDecoder(strings.NewReader(input))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fixture); err != nil {
+		return nil, fmt.Errorf("invalid test input: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	db, err := sql.Open(sqlDriverName, ":memory:")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	// Every connection to :memory: is a separate database
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	if err := loadFixture(ctx, db, fixture); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, queryError(ctx, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &sqlResult{Headers: columns, Values: [][]interface{}{}}
+	size := 0
+	for rows.Next() {
+		row := make([]interface{}, len(columns))
+		ptrs := make([]interface{}, len(columns))
+		for i := range row {
+			ptrs[i] = &row[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, queryError(ctx, err)
+		}
+		for i, v := range row {
+			if b, ok := v.([]byte); ok {
+				row[i] = string(b)
+			}
+			size += len(fmt.Sprint(row[i]))
+		}
+		// The rows are held in the server's memory, so they are bounded like a program's output
+		if size > maxOutputSize {
+			return nil, errSQLOutput
+		}
+		result.Values = append(result.Values, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, err)
+	}
+
+	return result, nil
+}
+
+// queryError maps a failed query to the limit it ran into, if any
+func queryError(ctx context.Context, err error) error {
+	var sqliteErr sqlite3.Error
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		return errSQLTimeout
+	case errors.As(err, &sqliteErr) && (sqliteErr.Code == sqlite3.ErrNomem || sqliteErr.Code == sqlite3.ErrTooBig):
+		return errSQLMemory
+	}
+	return err
+}
+
+// loadFixture inserts the fixture rows table by table
+func loadFixture(ctx context.Context, db *sql.DB, fixture sqlFixture) error {
+	tables := make([]string, 0, len(fixture.Rows))
+	for table := range fixture.Rows {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	for _, table := range tables {
+		columns := fixture.Headers[table]
+		if len(columns) == 0 {
+			return fmt.Errorf("invalid test input: no headers for table %s", table)
+		}
+
+		quoted := make([]string, len(columns))
+		for i, c := range columns {
+			quoted[i] = quoteIdentifier(c)
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+		stmt := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+			quoteIdentifier(table), strings.Join(quoted, ", "), placeholders)
+
+		for _, row := range fixture.Rows[table] {
+			if len(row) != len(columns) {
+				return fmt.Errorf("invalid test input: row %v does not match headers of table %s", row, table)
+			}
+			for i, v := range row {
+				row[i] = sqlFixtureValue(v)
+			}
+			if _, err := db.ExecContext(ctx, stmt, row...); err != nil {
+				return fmt.Errorf("invalid test input: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// sqlFixtureValue keeps integral JSON numbers as integers so they bind as INTEGER
+func sqlFixtureValue(v interface{}) interface{} {
+	n, ok := v.(json.Number)
+	if !ok {
+		return v
+	}
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	f, _ := n.Float64()
+	return f
+}
+
+// quoteIdentifier quotes a table or column name for SQLite
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// sqlResultsEqual compares column names and rows, ignoring row order unless ordered is set.
+// Both sides are normalized through JSON so 200 and 200.0 compare equal.
+func sqlResultsEqual(actual *sqlResult, expectedJSON string, ordered bool) bool {
+	var expected sqlResult
+	if err := json.Unmarshal([]byte(expectedJSON), &expected); err != nil {
+		return false
+	}
+
+	actualJSON, _ := json.Marshal(actual)
+	var normalized sqlResult
+	if err := json.Unmarshal(actualJSON, &normalized); err != nil {
+		return false
+	}
+
+	if !reflect.DeepEqual(normalized.Headers, expected.Headers) {
+		return false
+	}
+
+	actualRows := sqlRowKeys(normalized.Values)
+	expectedRows := sqlRowKeys(expected.Values)
+	if !ordered {
+		sort.Strings(actualRows)
+		sort.Strings(expectedRows)
+	}
+	return reflect.DeepEqual(actualRows, expectedRows)
+}
+
+// sqlRowKeys encodes each row so rows can be sorted and compared
+func sqlRowKeys(values [][]interface{}) []string {
+	keys := make([]string, len(values))
+	for i, row := range values {
+		key, _ := json.Marshal(row)
+		keys[i] = string(key)
+	}
+	return keys
+}
@@ -0,0 +1,46 @@
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	problemDomain "leetcode-api/internal/domain/problem"
+	submissionDomain "leetcode-api/internal/domain/submission"
+)
+
+func TestExecuteSQLLimits(t *testing.T) {
+	registry, err := DefaultRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := New(Config{Timeout: 5 * time.Second, Workers: 1, Languages: registry}, nil)
+	problem := &problemDomain.Problem{
+		Slug:     "numbers",
+		Database: &problemDomain.DatabaseSpec{Schema: "CREATE TABLE Numbers (n INT)"},
+	}
+	testCases := []problemDomain.TestCase{{
+		Input:    `{"headers": {"Numbers": ["n"]}, "rows": {"Numbers": [[1], [2]]}}`,
+		Expected: `{"headers": ["n"], "values": [[1], [2]]}`,
+	}}
+
+	tests := []struct {
+		name  string
+		query string
+		want  submissionDomain.Status
+	}{
+		{"accepted", "SELECT n FROM Numbers", submissionDomain.StatusAccepted},
+		{"syntax error", "SELEC n FROM Numbers", submissionDomain.StatusError},
+		{"heap limit is the server's", "PRAGMA hard_heap_limit = 1", submissionDomain.StatusError},
+		{"huge value", "WITH RECURSIVE r(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM r WHERE i < 1000000) SELECT group_concat(printf('%040d', i)) AS n FROM r", submissionDomain.StatusMemory},
+		{"huge result", "WITH RECURSIVE r(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM r WHERE i < 1000000) SELECT printf('%040d', i) AS n FROM r", submissionDomain.StatusOutput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := e.Execute(context.Background(), problem, "sql", tt.query, testCases, nil)[0]
+			if result.Status != tt.want {
+				t.Errorf("status = %s (%s), want %s", result.Status, result.Error, tt.want)
+			}
+		})
+	}
+}
@@ -8,7 +8,8 @@ import (
 
 // NewDB creates a new SQLite database connection
 func NewDB(path string) (*gorm.DB, error) {
-	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+	// Judge workers write while requests read; wait for locks instead of failing
+	db, err := gorm.Open(sqlite.Open(path+"?_busy_timeout=5000"), &gorm.Config{})
 	if err != nil {
 		return nil, err
 	}
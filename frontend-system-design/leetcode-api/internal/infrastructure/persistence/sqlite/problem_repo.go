@@ -3,9 +3,11 @@ package sqlite
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	domain "leetcode-api/internal/domain/problem"
 )
@@ -21,6 +23,15 @@ type ProblemModel struct {
 	Examples       string
 	Constraints    string
 	StarterCode    string
+	Signature      string // JSON-encoded signature
+	Database       string // JSON-encoded database spec
+	Concurrency    string // JSON-encoded concurrency spec
+	Design         string // JSON-encoded design spec
+	Interactive    string // JSON-encoded interactive spec
+	IOMode         bool   // solutions read stdin and write stdout
+	Checker        string // JSON-encoded checker spec
+	Reference      string // JSON-encoded reference solution
+	MemoryLimit    int    // in MB
 	AcceptanceRate float64
 	Submissions    int
 	Accepted       int
@@ -38,6 +49,7 @@ type TestCaseModel struct {
 	ProblemID uint
 	Input     string
 	Expected  string
+	Files     string // JSON-encoded fixture files
 	IsHidden  bool
 }
 
@@ -148,10 +160,23 @@ func (r *ProblemRepository) Create(ctx context.Context, problem *domain.Problem)
 	return nil
 }
 
-// Update updates an existing problem
+// Update updates an existing problem, replacing its test cases and topics
 func (r *ProblemRepository) Update(ctx context.Context, problem *domain.Problem) error {
 	model := toModelProblem(*problem)
-	return r.db.WithContext(ctx).Save(&model).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations, "CreatedAt").Save(&model).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("problem_id = ?", model.ID).Delete(&TestCaseModel{}).Error; err != nil {
+			return err
+		}
+		if len(model.TestCases) > 0 {
+			if err := tx.Create(&model.TestCases).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&model).Association("Topics").Replace(model.Topics)
+	})
 }
 
 // Delete deletes a problem by ID
@@ -198,11 +223,17 @@ func (r *ProblemRepository) GetTopics(ctx context.Context) ([]domain.Topic, erro
 func toDomainProblem(m ProblemModel) domain.Problem {
 	testCases := make([]domain.TestCase, len(m.TestCases))
 	for i, tc := range m.TestCases {
+		var files map[string]string
+		if tc.Files != "" {
+			json.Unmarshal([]byte(tc.Files), &files)
+		}
+
 		testCases[i] = domain.TestCase{
 			ID:        tc.ID,
 			ProblemID: tc.ProblemID,
 			Input:     tc.Input,
 			Expected:  tc.Expected,
+			Files:     files,
 			IsHidden:  tc.IsHidden,
 		}
 	}
@@ -226,6 +257,15 @@ func toDomainProblem(m ProblemModel) domain.Problem {
 		Examples:       m.Examples,
 		Constraints:    m.Constraints,
 		StarterCode:    m.StarterCode,
+		Signature:      decodeOptional[domain.Signature](m.Signature),
+		Database:       decodeOptional[domain.DatabaseSpec](m.Database),
+		Concurrency:    decodeOptional[domain.ConcurrencySpec](m.Concurrency),
+		Design:         decodeOptional[domain.DesignSpec](m.Design),
+		Interactive:    decodeOptional[domain.InteractiveSpec](m.Interactive),
+		IOMode:         m.IOMode,
+		Checker:        decodeOptional[domain.CheckerSpec](m.Checker),
+		Reference:      decodeOptional[domain.ReferenceSolution](m.Reference),
+		MemoryLimit:    m.MemoryLimit,
 		AcceptanceRate: m.AcceptanceRate,
 		Submissions:    m.Submissions,
 		Accepted:       m.Accepted,
@@ -240,10 +280,17 @@ func toDomainProblem(m ProblemModel) domain.Problem {
 func toModelProblem(p domain.Problem) ProblemModel {
 	testCases := make([]TestCaseModel, len(p.TestCases))
 	for i, tc := range p.TestCases {
+		var files string
+		if len(tc.Files) > 0 {
+			filesJSON, _ := json.Marshal(tc.Files)
+			files = string(filesJSON)
+		}
+
 		testCases[i] = TestCaseModel{
 			ProblemID: p.ID,
 			Input:     tc.Input,
 			Expected:  tc.Expected,
+			Files:     files,
 			IsHidden:  tc.IsHidden,
 		}
 	}
@@ -267,6 +314,15 @@ func toModelProblem(p domain.Problem) ProblemModel {
 		Examples:       p.Examples,
 		Constraints:    p.Constraints,
 		StarterCode:    p.StarterCode,
+		Signature:      encodeOptional(p.Signature),
+		Database:       encodeOptional(p.Database),
+		Concurrency:    encodeOptional(p.Concurrency),
+		Design:         encodeOptional(p.Design),
+		Interactive:    encodeOptional(p.Interactive),
+		IOMode:         p.IOMode,
+		Checker:        encodeOptional(p.Checker),
+		Reference:      encodeOptional(p.Reference),
+		MemoryLimit:    p.MemoryLimit,
 		AcceptanceRate: p.AcceptanceRate,
 		Submissions:    p.Submissions,
 		Accepted:       p.Accepted,
@@ -275,3 +331,24 @@ func toModelProblem(p domain.Problem) ProblemModel {
 		Topics:         topics,
 	}
 }
+
+// decodeOptional decodes a JSON column, returning nil when it is empty or malformed
+func decodeOptional[T any](data string) *T {
+	if data == "" {
+		return nil
+	}
+	v := new(T)
+	if err := json.Unmarshal([]byte(data), v); err != nil {
+		return nil
+	}
+	return v
+}
+
+// encodeOptional JSON-encodes optional metadata, storing nil as an empty column
+func encodeOptional[T any](v *T) string {
+	if v == nil {
+		return ""
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
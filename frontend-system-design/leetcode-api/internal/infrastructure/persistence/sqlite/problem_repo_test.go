@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	domain "leetcode-api/internal/domain/problem"
+)
+
+func TestUpdateReplacesTestCases(t *testing.T) {
+	ctx := context.Background()
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := NewProblemRepository(db)
+
+	// A problem seeded by an older version, before it had a signature
+	problem := &domain.Problem{
+		Slug:      "two-sum",
+		Title:     "Two Sum",
+		TestCases: []domain.TestCase{{Input: "old", Expected: "old"}},
+	}
+	if err := repo.Create(ctx, problem); err != nil {
+		t.Fatal(err)
+	}
+	created, err := repo.FindBySlug(ctx, "two-sum")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updated := &domain.Problem{
+		ID:        problem.ID,
+		Slug:      "two-sum",
+		Title:     "1. Two Sum",
+		Signature: &domain.Signature{FunctionName: "twoSum", ReturnType: "integer[]"},
+		TestCases: []domain.TestCase{{Input: "a", Expected: "1"}, {Input: "b", Expected: "2", IsHidden: true}},
+	}
+	if err := repo.Update(ctx, updated); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := repo.FindBySlug(ctx, "two-sum")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "1. Two Sum" || got.Signature == nil || got.Signature.FunctionName != "twoSum" {
+		t.Errorf("problem = %q with signature %+v, want the updated metadata", got.Title, got.Signature)
+	}
+	if len(got.TestCases) != 2 || got.TestCases[0].Input != "a" || !got.TestCases[1].IsHidden {
+		t.Errorf("test cases = %+v, want the two updated ones", got.TestCases)
+	}
+	if !got.CreatedAt.Equal(created.CreatedAt) {
+		t.Errorf("CreatedAt = %v, want %v kept", got.CreatedAt, created.CreatedAt)
+	}
+}
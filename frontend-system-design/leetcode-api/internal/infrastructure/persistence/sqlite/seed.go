@@ -47,6 +47,23 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Input: nums = [2,7,11,15], target = 9\nOutput: [0,1]`,
 			Constraints:    `2 <= nums.length <= 10^4`,
 			StarterCode:    `{"javascript": "function twoSum(nums, target) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "twoSum",
+				Params:       []domain.Param{{Name: "nums", Type: "integer[]"}, {Name: "target", Type: "integer"}},
+				ReturnType:   "integer[]",
+			},
+			Checker: &domain.CheckerSpec{Kind: domain.CheckerUnorderedList},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function twoSum(nums, target) {
+  const seen = new Map();
+  for (let i = 0; i < nums.length; i++) {
+    if (seen.has(target - nums[i])) return [seen.get(target - nums[i]), i];
+    seen.set(nums[i], i);
+  }
+  return [];
+}`,
+			},
 			TestCases: []domain.TestCase{
 				{Input: `[[2,7,11,15], 9]`, Expected: `[0,1]`, IsHidden: false},
 				{Input: `[[3,2,4], 6]`, Expected: `[1,2]`, IsHidden: false},
@@ -64,6 +81,18 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Input: x = 121\nOutput: true`,
 			Constraints:    `-2^31 <= x <= 2^31 - 1`,
 			StarterCode:    `{"javascript": "function isPalindrome(x) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "isPalindrome",
+				Params:       []domain.Param{{Name: "x", Type: "integer"}},
+				ReturnType:   "boolean",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function isPalindrome(x) {
+  const s = String(x);
+  return s === s.split('').reverse().join('');
+}`,
+			},
 			TestCases: []domain.TestCase{
 				{Input: `[121]`, Expected: `true`, IsHidden: false},
 			},
@@ -80,6 +109,26 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Input: s = "()"\nOutput: true`,
 			Constraints:    `1 <= s.length <= 10^4`,
 			StarterCode:    `{"javascript": "function isValid(s) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "isValid",
+				Params:       []domain.Param{{Name: "s", Type: "string"}},
+				ReturnType:   "boolean",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function isValid(s) {
+  const pairs = { ')': '(', ']': '[', '}': '{' };
+  const stack = [];
+  for (const c of s) {
+    if (c in pairs) {
+      if (stack.pop() !== pairs[c]) return false;
+    } else {
+      stack.push(c);
+    }
+  }
+  return stack.length === 0;
+}`,
+			},
 			TestCases: []domain.TestCase{
 				{Input: `["()"]`, Expected: `true`, IsHidden: false},
 			},
@@ -96,6 +145,30 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Input: l1 = [1,2,4], l2 = [1,3,4]\nOutput: [1,1,2,3,4,4]`,
 			Constraints:    `The number of nodes in both lists is in the range [0, 50].`,
 			StarterCode:    `{"javascript": "function mergeTwoLists(l1, l2) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "mergeTwoLists",
+				Params:       []domain.Param{{Name: "l1", Type: "ListNode"}, {Name: "l2", Type: "ListNode"}},
+				ReturnType:   "ListNode",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function mergeTwoLists(l1, l2) {
+  const dummy = new ListNode(0);
+  let tail = dummy;
+  while (l1 && l2) {
+    if (l1.val <= l2.val) {
+      tail.next = l1;
+      l1 = l1.next;
+    } else {
+      tail.next = l2;
+      l2 = l2.next;
+    }
+    tail = tail.next;
+  }
+  tail.next = l1 || l2;
+  return dummy.next;
+}`,
+			},
 			TestCases: []domain.TestCase{
 				{Input: `[[1,2,4], [1,3,4]]`, Expected: `[1,1,2,3,4,4]`, IsHidden: false},
 			},
@@ -112,6 +185,22 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Input: prices = [7,1,5,3,6,4]\nOutput: 5`,
 			Constraints:    `1 <= prices.length <= 10^5`,
 			StarterCode:    `{"javascript": "function maxProfit(prices) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "maxProfit",
+				Params:       []domain.Param{{Name: "prices", Type: "integer[]"}},
+				ReturnType:   "integer",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function maxProfit(prices) {
+  let min = Infinity, best = 0;
+  for (const p of prices) {
+    min = Math.min(min, p);
+    best = Math.max(best, p - min);
+  }
+  return best;
+}`,
+			},
 			TestCases: []domain.TestCase{
 				{Input: `[[7,1,5,3,6,4]]`, Expected: `5`, IsHidden: false},
 			},
@@ -128,6 +217,24 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Input: head = [1,2,3,4,5]\nOutput: [5,4,3,2,1]`,
 			Constraints:    `The number of nodes in the list is the range [0, 5000].`,
 			StarterCode:    `{"javascript": "function reverseList(head) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "reverseList",
+				Params:       []domain.Param{{Name: "head", Type: "ListNode"}},
+				ReturnType:   "ListNode",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function reverseList(head) {
+  let prev = null;
+  while (head) {
+    const next = head.next;
+    head.next = prev;
+    prev = head;
+    head = next;
+  }
+  return prev;
+}`,
+			},
 			TestCases: []domain.TestCase{
 				{Input: `[[1,2,3,4,5]]`, Expected: `[5,4,3,2,1]`, IsHidden: false},
 			},
@@ -144,6 +251,17 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Input: nums = [1,2,3,1]\nOutput: true`,
 			Constraints:    `1 <= nums.length <= 10^5`,
 			StarterCode:    `{"javascript": "function containsDuplicate(nums) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "containsDuplicate",
+				Params:       []domain.Param{{Name: "nums", Type: "integer[]"}},
+				ReturnType:   "boolean",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function containsDuplicate(nums) {
+  return new Set(nums).size !== nums.length;
+}`,
+			},
 			TestCases: []domain.TestCase{
 				{Input: `[[1,2,3,1]]`, Expected: `true`, IsHidden: false},
 			},
@@ -160,6 +278,22 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Input: nums = [-2,1,-3,4,-1,2,1,-5,4]\nOutput: 6`,
 			Constraints:    `1 <= nums.length <= 10^5`,
 			StarterCode:    `{"javascript": "function maxSubArray(nums) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "maxSubArray",
+				Params:       []domain.Param{{Name: "nums", Type: "integer[]"}},
+				ReturnType:   "integer",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function maxSubArray(nums) {
+  let best = nums[0], current = 0;
+  for (const n of nums) {
+    current = Math.max(n, current + n);
+    best = Math.max(best, current);
+  }
+  return best;
+}`,
+			},
 			TestCases: []domain.TestCase{
 				{Input: `[[-2,1,-3,4,-1,2,1,-5,4]]`, Expected: `6`, IsHidden: false},
 			},
@@ -177,6 +311,27 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Input: l1 = [2,4,3], l2 = [5,6,4]\nOutput: [7,0,8]`,
 			Constraints:    `The number of nodes in each linked list is in the range [1, 100].`,
 			StarterCode:    `{"javascript": "function addTwoNumbers(l1, l2) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "addTwoNumbers",
+				Params:       []domain.Param{{Name: "l1", Type: "ListNode"}, {Name: "l2", Type: "ListNode"}},
+				ReturnType:   "ListNode",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function addTwoNumbers(l1, l2) {
+  const dummy = new ListNode(0);
+  let tail = dummy, carry = 0;
+  while (l1 || l2 || carry) {
+    const sum = (l1 ? l1.val : 0) + (l2 ? l2.val : 0) + carry;
+    carry = Math.floor(sum / 10);
+    tail.next = new ListNode(sum % 10);
+    tail = tail.next;
+    l1 = l1 && l1.next;
+    l2 = l2 && l2.next;
+  }
+  return dummy.next;
+}`,
+			},
 			TestCases: []domain.TestCase{
 				{Input: `[[2,4,3], [5,6,4]]`, Expected: `[7,0,8]`, IsHidden: false},
 			},
@@ -193,6 +348,24 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Input: s = "abcabcbb"\nOutput: 3`,
 			Constraints:    `0 <= s.length <= 5 * 10^4`,
 			StarterCode:    `{"javascript": "function lengthOfLongestSubstring(s) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "lengthOfLongestSubstring",
+				Params:       []domain.Param{{Name: "s", Type: "string"}},
+				ReturnType:   "integer",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function lengthOfLongestSubstring(s) {
+  const last = new Map();
+  let start = 0, best = 0;
+  for (let i = 0; i < s.length; i++) {
+    if (last.has(s[i]) && last.get(s[i]) >= start) start = last.get(s[i]) + 1;
+    last.set(s[i], i);
+    best = Math.max(best, i - start + 1);
+  }
+  return best;
+}`,
+			},
 			TestCases: []domain.TestCase{
 				{Input: `["abcabcbb"]`, Expected: `3`, IsHidden: false},
 			},
@@ -209,6 +382,22 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Input: height = [1,8,6,2,5,4,8,3,7]\nOutput: 49`,
 			Constraints:    `n == height.length, 2 <= n <= 10^5`,
 			StarterCode:    `{"javascript": "function maxArea(height) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "maxArea",
+				Params:       []domain.Param{{Name: "height", Type: "integer[]"}},
+				ReturnType:   "integer",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function maxArea(height) {
+  let i = 0, j = height.length - 1, best = 0;
+  while (i < j) {
+    best = Math.max(best, Math.min(height[i], height[j]) * (j - i));
+    if (height[i] < height[j]) i++; else j--;
+  }
+  return best;
+}`,
+			},
 			TestCases: []domain.TestCase{
 				{Input: `[[1,8,6,2,5,4,8,3,7]]`, Expected: `49`, IsHidden: false},
 			},
@@ -225,6 +414,35 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Input: nums = [-1,0,1,2,-1,-4]\nOutput: [[-1,-1,2],[-1,0,1]]`,
 			Constraints:    `3 <= nums.length <= 3000`,
 			StarterCode:    `{"javascript": "function threeSum(nums) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "threeSum",
+				Params:       []domain.Param{{Name: "nums", Type: "integer[]"}},
+				ReturnType:   "integer[][]",
+			},
+			Checker: &domain.CheckerSpec{Kind: domain.CheckerUnorderedLists},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function threeSum(nums) {
+  nums = [...nums].sort((a, b) => a - b);
+  const result = [];
+  for (let i = 0; i < nums.length - 2; i++) {
+    if (i > 0 && nums[i] === nums[i - 1]) continue;
+    let j = i + 1, k = nums.length - 1;
+    while (j < k) {
+      const sum = nums[i] + nums[j] + nums[k];
+      if (sum < 0) j++;
+      else if (sum > 0) k--;
+      else {
+        result.push([nums[i], nums[j], nums[k]]);
+        while (j < k && nums[j] === nums[j + 1]) j++;
+        j++;
+        k--;
+      }
+    }
+  }
+  return result;
+}`,
+			},
 			TestCases: []domain.TestCase{
 				{Input: `[[-1,0,1,2,-1,-4]]`, Expected: `[[-1,-1,2],[-1,0,1]]`, IsHidden: false},
 			},
@@ -241,6 +459,24 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Input: digits = "23"\nOutput: ["ad","ae","af","bd","be","bf","cd","ce","cf"]`,
 			Constraints:    `0 <= digits.length <= 4`,
 			StarterCode:    `{"javascript": "function letterCombinations(digits) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "letterCombinations",
+				Params:       []domain.Param{{Name: "digits", Type: "string"}},
+				ReturnType:   "string[]",
+			},
+			Checker: &domain.CheckerSpec{Kind: domain.CheckerUnorderedList},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function letterCombinations(digits) {
+  if (digits === '') return [];
+  const keys = { 2: 'abc', 3: 'def', 4: 'ghi', 5: 'jkl', 6: 'mno', 7: 'pqrs', 8: 'tuv', 9: 'wxyz' };
+  let result = [''];
+  for (const d of digits) {
+    result = result.flatMap(prefix => [...keys[d]].map(c => prefix + c));
+  }
+  return result;
+}`,
+			},
 			TestCases: []domain.TestCase{
 				{Input: `["23"]`, Expected: `["ad","ae","af","bd","be","bf","cd","ce","cf"]`, IsHidden: false},
 			},
@@ -257,6 +493,25 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Input: n = 3\nOutput: ["((()))","(()())","(())()","()(())","()()()"]`,
 			Constraints:    `1 <= n <= 8`,
 			StarterCode:    `{"javascript": "function generateParenthesis(n) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "generateParenthesis",
+				Params:       []domain.Param{{Name: "n", Type: "integer"}},
+				ReturnType:   "string[]",
+			},
+			Checker: &domain.CheckerSpec{Kind: domain.CheckerUnorderedList},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function generateParenthesis(n) {
+  const result = [];
+  const build = (s, open, close) => {
+    if (s.length === 2 * n) return result.push(s);
+    if (open < n) build(s + '(', open + 1, close);
+    if (close < open) build(s + ')', open, close + 1);
+  };
+  build('', 0, 0);
+  return result;
+}`,
+			},
 			TestCases: []domain.TestCase{
 				{Input: `[3]`, Expected: `["((()))","(()())","(())()","()(())","()()()"]`, IsHidden: false},
 			},
@@ -273,6 +528,27 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Input: nums = [4,5,6,7,0,1,2], target = 0\nOutput: 4`,
 			Constraints:    `1 <= nums.length <= 5000`,
 			StarterCode:    `{"javascript": "function search(nums, target) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "search",
+				Params:       []domain.Param{{Name: "nums", Type: "integer[]"}, {Name: "target", Type: "integer"}},
+				ReturnType:   "integer",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function search(nums, target) {
+  let lo = 0, hi = nums.length - 1;
+  while (lo <= hi) {
+    const mid = (lo + hi) >> 1;
+    if (nums[mid] === target) return mid;
+    if (nums[lo] <= nums[mid]) {
+      if (nums[lo] <= target && target < nums[mid]) hi = mid - 1; else lo = mid + 1;
+    } else {
+      if (nums[mid] < target && target <= nums[hi]) lo = mid + 1; else hi = mid - 1;
+    }
+  }
+  return -1;
+}`,
+			},
 			TestCases: []domain.TestCase{
 				{Input: `[[4,5,6,7,0,1,2], 0]`, Expected: `4`, IsHidden: false},
 			},
@@ -289,6 +565,24 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Input: strs = ["eat","tea","tan","ate","nat","bat"]\nOutput: [["bat"],["nat","tan"],["ate","eat","tea"]]`,
 			Constraints:    `1 <= strs.length <= 10^4`,
 			StarterCode:    `{"javascript": "function groupAnagrams(strs) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "groupAnagrams",
+				Params:       []domain.Param{{Name: "strs", Type: "string[]"}},
+				ReturnType:   "string[][]",
+			},
+			Checker: &domain.CheckerSpec{Kind: domain.CheckerUnorderedLists},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function groupAnagrams(strs) {
+  const groups = new Map();
+  for (const s of strs) {
+    const key = [...s].sort().join('');
+    if (!groups.has(key)) groups.set(key, []);
+    groups.get(key).push(s);
+  }
+  return [...groups.values()];
+}`,
+			},
 			TestCases: []domain.TestCase{
 				{Input: `[["eat","tea","tan","ate","nat","bat"]]`, Expected: `[["eat","tea","ate"],["tan","nat"],["bat"]]`, IsHidden: false},
 			},
@@ -307,6 +601,20 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Input: nums1 = [1,3], nums2 = [2]\nOutput: 2.00000`,
 			Constraints:    `nums1.length == m, nums2.length == n, 0 <= m <= 1000`,
 			StarterCode:    `{"javascript": "function findMedianSortedArrays(nums1, nums2) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "findMedianSortedArrays",
+				Params:       []domain.Param{{Name: "nums1", Type: "integer[]"}, {Name: "nums2", Type: "integer[]"}},
+				ReturnType:   "double",
+			},
+			Checker: &domain.CheckerSpec{Kind: domain.CheckerFloat},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function findMedianSortedArrays(nums1, nums2) {
+  const merged = [...nums1, ...nums2].sort((a, b) => a - b);
+  const mid = merged.length >> 1;
+  return merged.length % 2 ? merged[mid] : (merged[mid - 1] + merged[mid]) / 2;
+}`,
+			},
 			TestCases: []domain.TestCase{
 				{Input: `[[1,3], [2]]`, Expected: `2.0`, IsHidden: false},
 			},
@@ -324,6 +632,26 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Input: s = "aa", p = "a"\nOutput: false`,
 			Constraints:    `1 <= s.length <= 20, 1 <= p.length <= 20`,
 			StarterCode:    `{"javascript": "function isMatch(s, p) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "isMatch",
+				Params:       []domain.Param{{Name: "s", Type: "string"}, {Name: "p", Type: "string"}},
+				ReturnType:   "boolean",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function isMatch(s, p) {
+  const dp = Array.from({ length: s.length + 1 }, () => Array(p.length + 1).fill(false));
+  dp[s.length][p.length] = true;
+  for (let i = s.length; i >= 0; i--) {
+    for (let j = p.length - 1; j >= 0; j--) {
+      const first = i < s.length && (p[j] === s[i] || p[j] === '.');
+      if (p[j + 1] === '*') dp[i][j] = dp[i][j + 2] || (first && dp[i + 1][j]);
+      else dp[i][j] = first && dp[i + 1][j + 1];
+    }
+  }
+  return dp[0][0];
+}`,
+			},
 			TestCases: []domain.TestCase{
 				{Input: `["aa", "a"]`, Expected: `false`, IsHidden: false},
 			},
@@ -341,6 +669,24 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Input: lists = [[1,4,5],[1,3,4],[2,6]]\nOutput: [1,1,2,3,4,4,5,6]`,
 			Constraints:    `k == lists.length, 0 <= k <= 10^4`,
 			StarterCode:    `{"javascript": "function mergeKLists(lists) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "mergeKLists",
+				Params:       []domain.Param{{Name: "lists", Type: "ListNode[]"}},
+				ReturnType:   "ListNode",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function mergeKLists(lists) {
+  const values = [];
+  for (let node of lists) {
+    for (; node; node = node.next) values.push(node.val);
+  }
+  values.sort((a, b) => a - b);
+  let head = null;
+  for (let i = values.length - 1; i >= 0; i--) head = new ListNode(values[i], head);
+  return head;
+}`,
+			},
 			TestCases: []domain.TestCase{
 				{Input: `[[[1,4,5],[1,3,4],[2,6]]]`, Expected: `[1,1,2,3,4,4,5,6]`, IsHidden: false},
 			},
@@ -358,10 +704,807 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Input: height = [0,1,0,2,1,0,1,3,2,1,2,1]\nOutput: 6`,
 			Constraints:    `n == height.length, 1 <= n <= 2 * 10^4`,
 			StarterCode:    `{"javascript": "function trap(height) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "trap",
+				Params:       []domain.Param{{Name: "height", Type: "integer[]"}},
+				ReturnType:   "integer",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function trap(height) {
+  let i = 0, j = height.length - 1, leftMax = 0, rightMax = 0, water = 0;
+  while (i < j) {
+    if (height[i] < height[j]) {
+      leftMax = Math.max(leftMax, height[i]);
+      water += leftMax - height[i++];
+    } else {
+      rightMax = Math.max(rightMax, height[j]);
+      water += rightMax - height[j--];
+    }
+  }
+  return water;
+}`,
+			},
 			TestCases: []domain.TestCase{
 				{Input: `[[0,1,0,2,1,0,1,3,2,1,2,1]]`, Expected: `6`, IsHidden: false},
 			},
 		},
+		// Binary tree problems
+		{
+			Slug:           "binary-tree-inorder-traversal",
+			Title:          "94. Binary Tree Inorder Traversal",
+			Difficulty:     domain.Easy,
+			Category:       domain.CategoryAlgorithms,
+			AcceptanceRate: 77.4,
+			Submissions:    3800000,
+			Accepted:       2941200,
+			Description:    `Given the root of a binary tree, return the inorder traversal of its nodes' values.`,
+			Examples:       `Input: root = [1,null,2,3]\nOutput: [1,3,2]`,
+			Constraints:    `The number of nodes in the tree is in the range [0, 100].`,
+			StarterCode:    `{"javascript": "function inorderTraversal(root) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "inorderTraversal",
+				Params:       []domain.Param{{Name: "root", Type: "TreeNode"}},
+				ReturnType:   "integer[]",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function inorderTraversal(root) {
+  const result = [];
+  const visit = (node) => {
+    if (!node) return;
+    visit(node.left);
+    result.push(node.val);
+    visit(node.right);
+  };
+  visit(root);
+  return result;
+}`,
+			},
+			TestCases: []domain.TestCase{
+				{Input: `[[1,null,2,3]]`, Expected: `[1,3,2]`, IsHidden: false},
+				{Input: `[[]]`, Expected: `[]`, IsHidden: false},
+				{Input: `[[1,2,3,4,5,null,8,null,null,6,7,9]]`, Expected: `[4,2,6,5,7,1,3,9,8]`, IsHidden: true},
+			},
+		},
+		{
+			Slug:           "same-tree",
+			Title:          "100. Same Tree",
+			Difficulty:     domain.Easy,
+			Category:       domain.CategoryAlgorithms,
+			AcceptanceRate: 62.0,
+			Submissions:    4200000,
+			Accepted:       2604000,
+			Description:    `Given the roots of two binary trees p and q, check whether they are the same: structurally identical, with the same node values.`,
+			Examples:       `Input: p = [1,2,3], q = [1,2,3]\nOutput: true`,
+			Constraints:    `The number of nodes in both trees is in the range [0, 100].`,
+			StarterCode:    `{"javascript": "function isSameTree(p, q) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "isSameTree",
+				Params:       []domain.Param{{Name: "p", Type: "TreeNode"}, {Name: "q", Type: "TreeNode"}},
+				ReturnType:   "boolean",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function isSameTree(p, q) {
+  if (!p || !q) return p === q;
+  return p.val === q.val && isSameTree(p.left, q.left) && isSameTree(p.right, q.right);
+}`,
+			},
+			TestCases: []domain.TestCase{
+				{Input: `[[1,2,3], [1,2,3]]`, Expected: `true`, IsHidden: false},
+				{Input: `[[1,2], [1,null,2]]`, Expected: `false`, IsHidden: false},
+				{Input: `[[1,2,1], [1,1,2]]`, Expected: `false`, IsHidden: true},
+				{Input: `[[], []]`, Expected: `true`, IsHidden: true},
+			},
+		},
+		{
+			Slug:           "symmetric-tree",
+			Title:          "101. Symmetric Tree",
+			Difficulty:     domain.Easy,
+			Category:       domain.CategoryAlgorithms,
+			AcceptanceRate: 56.9,
+			Submissions:    4000000,
+			Accepted:       2276000,
+			Description:    `Given the root of a binary tree, check whether it is a mirror of itself (i.e., symmetric around its center).`,
+			Examples:       `Input: root = [1,2,2,3,4,4,3]\nOutput: true`,
+			Constraints:    `The number of nodes in the tree is in the range [1, 1000].`,
+			StarterCode:    `{"javascript": "function isSymmetric(root) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "isSymmetric",
+				Params:       []domain.Param{{Name: "root", Type: "TreeNode"}},
+				ReturnType:   "boolean",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function isSymmetric(root) {
+  const mirror = (a, b) => {
+    if (!a || !b) return a === b;
+    return a.val === b.val && mirror(a.left, b.right) && mirror(a.right, b.left);
+  };
+  return mirror(root.left, root.right);
+}`,
+			},
+			TestCases: []domain.TestCase{
+				{Input: `[[1,2,2,3,4,4,3]]`, Expected: `true`, IsHidden: false},
+				{Input: `[[1,2,2,null,3,null,3]]`, Expected: `false`, IsHidden: false},
+				{Input: `[[1]]`, Expected: `true`, IsHidden: true},
+				{Input: `[[1,2,2,2,null,2]]`, Expected: `false`, IsHidden: true},
+			},
+		},
+		{
+			Slug:           "maximum-depth-of-binary-tree",
+			Title:          "104. Maximum Depth of Binary Tree",
+			Difficulty:     domain.Easy,
+			Category:       domain.CategoryAlgorithms,
+			AcceptanceRate: 76.2,
+			Submissions:    6100000,
+			Accepted:       4648200,
+			Description:    `Given the root of a binary tree, return its maximum depth: the number of nodes along the longest path from the root node down to the farthest leaf node.`,
+			Examples:       `Input: root = [3,9,20,null,null,15,7]\nOutput: 3`,
+			Constraints:    `The number of nodes in the tree is in the range [0, 10^4].`,
+			StarterCode:    `{"javascript": "function maxDepth(root) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "maxDepth",
+				Params:       []domain.Param{{Name: "root", Type: "TreeNode"}},
+				ReturnType:   "integer",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function maxDepth(root) {
+  let depth = 0;
+  let level = root ? [root] : [];
+  while (level.length) {
+    depth++;
+    level = level.flatMap((node) => [node.left, node.right].filter(Boolean));
+  }
+  return depth;
+}`,
+			},
+			TestCases: []domain.TestCase{
+				{Input: `[[3,9,20,null,null,15,7]]`, Expected: `3`, IsHidden: false},
+				{Input: `[[1,null,2]]`, Expected: `2`, IsHidden: false},
+				{Input: `[[]]`, Expected: `0`, IsHidden: true},
+			},
+		},
+		{
+			Slug:           "convert-sorted-array-to-binary-search-tree",
+			Title:          "108. Convert Sorted Array to Binary Search Tree",
+			Difficulty:     domain.Easy,
+			Category:       domain.CategoryAlgorithms,
+			AcceptanceRate: 73.9,
+			Submissions:    2000000,
+			Accepted:       1478000,
+			Description:    `Given an integer array nums where the elements are sorted in ascending order, convert it to a height-balanced binary search tree. Any such tree is accepted.`,
+			Examples:       `Input: nums = [-10,-3,0,5,9]\nOutput: [0,-3,9,-10,null,5]`,
+			Constraints:    `1 <= nums.length <= 10^4, nums is sorted in a strictly increasing order.`,
+			StarterCode:    `{"javascript": "function sortedArrayToBST(nums) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "sortedArrayToBST",
+				Params:       []domain.Param{{Name: "nums", Type: "integer[]"}},
+				ReturnType:   "TreeNode",
+			},
+			Checker: &domain.CheckerSpec{
+				Kind:     domain.CheckerProgram,
+				Language: "javascript",
+				Code: `const fs = require('fs');
+const { input, actual } = JSON.parse(fs.readFileSync(0, 'utf8'));
+const [nums] = JSON.parse(input);
+let values;
+try {
+  values = JSON.parse(actual);
+} catch (e) {
+  process.exit(1);
+}
+if (!Array.isArray(values)) process.exit(1);
+
+// Rebuild the tree from its level order
+const nodes = values.map((v) => (v === null ? null : { val: v, left: null, right: null }));
+for (let i = 0, child = 1; i < nodes.length && child < nodes.length; i++) {
+  if (nodes[i] === null) continue;
+  nodes[i].left = nodes[child++];
+  if (child < nodes.length) nodes[i].right = nodes[child++];
+}
+
+// A height-balanced BST of nums has nums as its in-order traversal
+const inorder = [];
+let balanced = true;
+const height = (node) => {
+  if (!node) return 0;
+  const left = height(node.left);
+  inorder.push(node.val);
+  const right = height(node.right);
+  if (Math.abs(left - right) > 1) balanced = false;
+  return 1 + Math.max(left, right);
+};
+height(nodes.length ? nodes[0] : null);
+const same = inorder.length === nums.length && inorder.every((v, i) => v === nums[i]);
+process.exit(balanced && same ? 0 : 1);`,
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function sortedArrayToBST(nums) {
+  const build = (lo, hi) => {
+    if (lo > hi) return null;
+    const mid = (lo + hi) >> 1;
+    return new TreeNode(nums[mid], build(lo, mid - 1), build(mid + 1, hi));
+  };
+  return build(0, nums.length - 1);
+}`,
+			},
+			TestCases: []domain.TestCase{
+				{Input: `[[-10,-3,0,5,9]]`, Expected: `[0,-3,9,-10,null,5]`, IsHidden: false},
+				{Input: `[[1,3]]`, Expected: `[1,null,3]`, IsHidden: false},
+				{Input: `[[0]]`, Expected: `[0]`, IsHidden: true},
+				{Input: `[[1,2,3,4,5,6,7]]`, Expected: `[4,2,6,1,3,5,7]`, IsHidden: true},
+			},
+		},
+		{
+			Slug:           "balanced-binary-tree",
+			Title:          "110. Balanced Binary Tree",
+			Difficulty:     domain.Easy,
+			Category:       domain.CategoryAlgorithms,
+			AcceptanceRate: 53.5,
+			Submissions:    2900000,
+			Accepted:       1551500,
+			Description:    `Given a binary tree, determine if it is height-balanced: the depths of the two subtrees of every node never differ by more than one.`,
+			Examples:       `Input: root = [3,9,20,null,null,15,7]\nOutput: true`,
+			Constraints:    `The number of nodes in the tree is in the range [0, 5000].`,
+			StarterCode:    `{"javascript": "function isBalanced(root) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "isBalanced",
+				Params:       []domain.Param{{Name: "root", Type: "TreeNode"}},
+				ReturnType:   "boolean",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function isBalanced(root) {
+  // The height of a balanced subtree, or -1 for an unbalanced one
+  const height = (node) => {
+    if (!node) return 0;
+    const left = height(node.left);
+    const right = height(node.right);
+    if (left < 0 || right < 0 || Math.abs(left - right) > 1) return -1;
+    return 1 + Math.max(left, right);
+  };
+  return height(root) >= 0;
+}`,
+			},
+			TestCases: []domain.TestCase{
+				{Input: `[[3,9,20,null,null,15,7]]`, Expected: `true`, IsHidden: false},
+				{Input: `[[1,2,2,3,3,null,null,4,4]]`, Expected: `false`, IsHidden: false},
+				{Input: `[[]]`, Expected: `true`, IsHidden: true},
+			},
+		},
+		{
+			Slug:           "minimum-depth-of-binary-tree",
+			Title:          "111. Minimum Depth of Binary Tree",
+			Difficulty:     domain.Easy,
+			Category:       domain.CategoryAlgorithms,
+			AcceptanceRate: 49.6,
+			Submissions:    2500000,
+			Accepted:       1240000,
+			Description:    `Given a binary tree, find its minimum depth: the number of nodes along the shortest path from the root node down to the nearest leaf node.`,
+			Examples:       `Input: root = [3,9,20,null,null,15,7]\nOutput: 2`,
+			Constraints:    `The number of nodes in the tree is in the range [0, 10^5].`,
+			StarterCode:    `{"javascript": "function minDepth(root) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "minDepth",
+				Params:       []domain.Param{{Name: "root", Type: "TreeNode"}},
+				ReturnType:   "integer",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function minDepth(root) {
+  let depth = 0;
+  let level = root ? [root] : [];
+  while (level.length) {
+    depth++;
+    if (level.some((node) => !node.left && !node.right)) return depth;
+    level = level.flatMap((node) => [node.left, node.right].filter(Boolean));
+  }
+  return depth;
+}`,
+			},
+			TestCases: []domain.TestCase{
+				{Input: `[[3,9,20,null,null,15,7]]`, Expected: `2`, IsHidden: false},
+				{Input: `[[2,null,3,null,4,null,5,null,6]]`, Expected: `5`, IsHidden: false},
+				{Input: `[[]]`, Expected: `0`, IsHidden: true},
+			},
+		},
+		{
+			Slug:           "invert-binary-tree",
+			Title:          "226. Invert Binary Tree",
+			Difficulty:     domain.Easy,
+			Category:       domain.CategoryAlgorithms,
+			AcceptanceRate: 77.9,
+			Submissions:    3200000,
+			Accepted:       2492800,
+			Description:    `Given the root of a binary tree, invert the tree, and return its root.`,
+			Examples:       `Input: root = [4,2,7,1,3,6,9]\nOutput: [4,7,2,9,6,3,1]`,
+			Constraints:    `The number of nodes in the tree is in the range [0, 100].`,
+			StarterCode:    `{"javascript": "function invertTree(root) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "invertTree",
+				Params:       []domain.Param{{Name: "root", Type: "TreeNode"}},
+				ReturnType:   "TreeNode",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function invertTree(root) {
+  if (root) {
+    [root.left, root.right] = [invertTree(root.right), invertTree(root.left)];
+  }
+  return root;
+}`,
+			},
+			TestCases: []domain.TestCase{
+				{Input: `[[4,2,7,1,3,6,9]]`, Expected: `[4,7,2,9,6,3,1]`, IsHidden: false},
+				{Input: `[[2,1,3]]`, Expected: `[2,3,1]`, IsHidden: false},
+				{Input: `[[]]`, Expected: `[]`, IsHidden: true},
+			},
+		},
+		{
+			Slug:           "diameter-of-binary-tree",
+			Title:          "543. Diameter of Binary Tree",
+			Difficulty:     domain.Easy,
+			Category:       domain.CategoryAlgorithms,
+			AcceptanceRate: 61.8,
+			Submissions:    2700000,
+			Accepted:       1668600,
+			Description:    `Given the root of a binary tree, return the length of its diameter: the number of edges on the longest path between any two nodes, which may or may not pass through the root.`,
+			Examples:       `Input: root = [1,2,3,4,5]\nOutput: 3`,
+			Constraints:    `The number of nodes in the tree is in the range [1, 10^4].`,
+			StarterCode:    `{"javascript": "function diameterOfBinaryTree(root) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "diameterOfBinaryTree",
+				Params:       []domain.Param{{Name: "root", Type: "TreeNode"}},
+				ReturnType:   "integer",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function diameterOfBinaryTree(root) {
+  let best = 0;
+  const height = (node) => {
+    if (!node) return 0;
+    const left = height(node.left);
+    const right = height(node.right);
+    best = Math.max(best, left + right);
+    return 1 + Math.max(left, right);
+  };
+  height(root);
+  return best;
+}`,
+			},
+			TestCases: []domain.TestCase{
+				{Input: `[[1,2,3,4,5]]`, Expected: `3`, IsHidden: false},
+				{Input: `[[1,2]]`, Expected: `1`, IsHidden: false},
+				{Input: `[[4,-7,-3,null,null,-9,-3,9,-7,-4,null,6,null,-6,-6,null,null,0,6,5,null,9,null,null,-1,-4,null,null,null,-2]]`, Expected: `8`, IsHidden: true},
+			},
+		},
+		{
+			Slug:           "subtree-of-another-tree",
+			Title:          "572. Subtree of Another Tree",
+			Difficulty:     domain.Easy,
+			Category:       domain.CategoryAlgorithms,
+			AcceptanceRate: 49.6,
+			Submissions:    1900000,
+			Accepted:       942400,
+			Description:    `Given the roots of two binary trees root and subRoot, return true if there is a subtree of root with the same structure and node values as subRoot.`,
+			Examples:       `Input: root = [3,4,5,1,2], subRoot = [4,1,2]\nOutput: true`,
+			Constraints:    `The number of nodes in root is in the range [1, 2000], and in subRoot in the range [1, 1000].`,
+			StarterCode:    `{"javascript": "function isSubtree(root, subRoot) {\n  // Your code here\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "isSubtree",
+				Params:       []domain.Param{{Name: "root", Type: "TreeNode"}, {Name: "subRoot", Type: "TreeNode"}},
+				ReturnType:   "boolean",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function isSubtree(root, subRoot) {
+  const same = (a, b) => {
+    if (!a || !b) return a === b;
+    return a.val === b.val && same(a.left, b.left) && same(a.right, b.right);
+  };
+  const search = (node) => !!node && (same(node, subRoot) || search(node.left) || search(node.right));
+  return search(root);
+}`,
+			},
+			TestCases: []domain.TestCase{
+				{Input: `[[3,4,5,1,2], [4,1,2]]`, Expected: `true`, IsHidden: false},
+				{Input: `[[3,4,5,1,2,null,null,null,null,0], [4,1,2]]`, Expected: `false`, IsHidden: false},
+				{Input: `[[1,1], [1]]`, Expected: `true`, IsHidden: true},
+			},
+		},
+		// In-place problems, judged by the argument the solution modifies
+		{
+			Slug:           "move-zeroes",
+			Title:          "283. Move Zeroes",
+			Difficulty:     domain.Easy,
+			Category:       domain.CategoryAlgorithms,
+			AcceptanceRate: 61.7,
+			Submissions:    4000000,
+			Accepted:       2468000,
+			Description:    `Given an integer array nums, move all 0's to the end of it while maintaining the relative order of the non-zero elements. Note that you must do this in-place without making a copy of the array.`,
+			Examples:       `Input: nums = [0,1,0,3,12]\nOutput: [1,3,12,0,0]`,
+			Constraints:    `1 <= nums.length <= 10^4, -2^31 <= nums[i] <= 2^31 - 1`,
+			StarterCode:    `{"javascript": "function moveZeroes(nums) {\n  // Modify nums in-place\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "moveZeroes",
+				Params:       []domain.Param{{Name: "nums", Type: "integer[]"}},
+				ReturnType:   "void",
+				OutputParam:  "nums",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function moveZeroes(nums) {
+  let next = 0;
+  for (const num of nums) {
+    if (num !== 0) nums[next++] = num;
+  }
+  nums.fill(0, next);
+}`,
+			},
+			TestCases: []domain.TestCase{
+				{Input: `[[0,1,0,3,12]]`, Expected: `[1,3,12,0,0]`, IsHidden: false},
+				{Input: `[[0]]`, Expected: `[0]`, IsHidden: false},
+				{Input: `[[4,0,0,-2147483648,0,2147483647]]`, Expected: `[4,-2147483648,2147483647,0,0,0]`, IsHidden: true},
+			},
+		},
+		{
+			Slug:           "rotate-image",
+			Title:          "48. Rotate Image",
+			Difficulty:     domain.Medium,
+			Category:       domain.CategoryAlgorithms,
+			AcceptanceRate: 75.5,
+			Submissions:    2600000,
+			Accepted:       1963000,
+			Description:    `You are given an n x n 2D matrix representing an image, rotate the image by 90 degrees (clockwise). You have to rotate the image in-place, which means you have to modify the input 2D matrix directly. DO NOT allocate another 2D matrix and do the rotation.`,
+			Examples:       `Input: matrix = [[1,2,3],[4,5,6],[7,8,9]]\nOutput: [[7,4,1],[8,5,2],[9,6,3]]`,
+			Constraints:    `n == matrix.length == matrix[i].length, 1 <= n <= 20, -1000 <= matrix[i][j] <= 1000`,
+			StarterCode:    `{"javascript": "function rotate(matrix) {\n  // Modify matrix in-place\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "rotate",
+				Params:       []domain.Param{{Name: "matrix", Type: "integer[][]"}},
+				ReturnType:   "void",
+				OutputParam:  "matrix",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function rotate(matrix) {
+  const n = matrix.length;
+  for (let i = 0; i < n; i++) {
+    for (let j = i + 1; j < n; j++) {
+      [matrix[i][j], matrix[j][i]] = [matrix[j][i], matrix[i][j]];
+    }
+  }
+  for (const row of matrix) row.reverse();
+}`,
+			},
+			TestCases: []domain.TestCase{
+				{Input: `[[[1,2,3],[4,5,6],[7,8,9]]]`, Expected: `[[7,4,1],[8,5,2],[9,6,3]]`, IsHidden: false},
+				{Input: `[[[5,1,9,11],[2,4,8,10],[13,3,6,7],[15,14,12,16]]]`, Expected: `[[15,13,2,5],[14,3,4,1],[12,6,8,9],[16,7,10,11]]`, IsHidden: false},
+				{Input: `[[[1]]]`, Expected: `[[1]]`, IsHidden: true},
+			},
+		},
+		{
+			Slug:           "sort-colors",
+			Title:          "75. Sort Colors",
+			Difficulty:     domain.Medium,
+			Category:       domain.CategoryAlgorithms,
+			AcceptanceRate: 63.4,
+			Submissions:    3000000,
+			Accepted:       1902000,
+			Description:    `Given an array nums with n objects colored red, white, or blue, sort them in-place so that objects of the same color are adjacent, with the colors in the order red, white, and blue. The integers 0, 1, and 2 represent red, white, and blue respectively. You must solve this problem without using the library's sort function.`,
+			Examples:       `Input: nums = [2,0,2,1,1,0]\nOutput: [0,0,1,1,2,2]`,
+			Constraints:    `n == nums.length, 1 <= n <= 300, nums[i] is either 0, 1, or 2.`,
+			StarterCode:    `{"javascript": "function sortColors(nums) {\n  // Modify nums in-place\n}"}`,
+			Signature: &domain.Signature{
+				FunctionName: "sortColors",
+				Params:       []domain.Param{{Name: "nums", Type: "integer[]"}},
+				ReturnType:   "void",
+				OutputParam:  "nums",
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `function sortColors(nums) {
+  const swap = (i, j) => {
+    [nums[i], nums[j]] = [nums[j], nums[i]];
+  };
+  let low = 0, mid = 0, high = nums.length - 1;
+  while (mid <= high) {
+    if (nums[mid] === 0) {
+      swap(low++, mid++);
+    } else if (nums[mid] === 2) {
+      swap(mid, high--);
+    } else {
+      mid++;
+    }
+  }
+}`,
+			},
+			TestCases: []domain.TestCase{
+				{Input: `[[2,0,2,1,1,0]]`, Expected: `[0,0,1,1,2,2]`, IsHidden: false},
+				{Input: `[[2,0,1]]`, Expected: `[0,1,2]`, IsHidden: false},
+				{Input: `[[1,2,0,0,2,1,1,2,0]]`, Expected: `[0,0,0,1,1,1,2,2,2]`, IsHidden: true},
+			},
+		},
+		// Design problems
+		{
+			Slug:           "lru-cache",
+			Title:          "146. LRU Cache",
+			Difficulty:     domain.Medium,
+			Category:       domain.CategoryAlgorithms,
+			AcceptanceRate: 43.9,
+			Submissions:    4300000,
+			Accepted:       1887700,
+			Description:    `Design a data structure that follows the constraints of a Least Recently Used (LRU) cache. LRUCache(capacity) initializes the cache with a positive capacity. get(key) returns the value of the key if it exists, otherwise -1. put(key, value) updates the value of the key if it exists, otherwise adds the key-value pair; if the number of keys exceeds the capacity, it evicts the least recently used key. get and put must each run in O(1) average time.`,
+			Examples:       `Input: ["LRUCache","put","put","get","put","get","put","get","get","get"], [[2],[1,1],[2,2],[1],[3,3],[2],[4,4],[1],[3],[4]]\nOutput: [null,null,null,1,null,-1,null,-1,3,4]`,
+			Constraints:    `1 <= capacity <= 3000, 0 <= key <= 10^4, 0 <= value <= 10^5, at most 2 * 10^5 calls will be made to get and put.`,
+			StarterCode:    `{"javascript": "class LRUCache {\n  constructor(capacity) {\n    // Your code here\n  }\n\n  get(key) {\n    // Your code here\n  }\n\n  put(key, value) {\n    // Your code here\n  }\n}"}`,
+			Design: &domain.DesignSpec{
+				ClassName:   "LRUCache",
+				Constructor: []domain.Param{{Name: "capacity", Type: "integer"}},
+				Methods: []domain.Signature{
+					{FunctionName: "get", Params: []domain.Param{{Name: "key", Type: "integer"}}, ReturnType: "integer"},
+					{FunctionName: "put", Params: []domain.Param{{Name: "key", Type: "integer"}, {Name: "value", Type: "integer"}}, ReturnType: "void"},
+				},
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `class LRUCache {
+  constructor(capacity) {
+    this.capacity = capacity;
+    this.entries = new Map();
+  }
+
+  get(key) {
+    if (!this.entries.has(key)) return -1;
+    const value = this.entries.get(key);
+    this.entries.delete(key);
+    this.entries.set(key, value);
+    return value;
+  }
+
+  put(key, value) {
+    this.entries.delete(key);
+    this.entries.set(key, value);
+    if (this.entries.size > this.capacity) {
+      this.entries.delete(this.entries.keys().next().value);
+    }
+  }
+}`,
+			},
+			TestCases: []domain.TestCase{
+				{Input: `[["LRUCache","put","put","get","put","get","put","get","get","get"],[[2],[1,1],[2,2],[1],[3,3],[2],[4,4],[1],[3],[4]]]`, Expected: `[null,null,null,1,null,-1,null,-1,3,4]`, IsHidden: false},
+				{Input: `[["LRUCache","put","get","put","get","get"],[[1],[2,1],[2],[3,2],[2],[3]]]`, Expected: `[null,null,1,null,-1,2]`, IsHidden: false},
+				{Input: `[["LRUCache","put","put","put","get","put","get","get","get"],[[2],[2,1],[1,1],[2,3],[2],[4,1],[1],[2],[4]]]`, Expected: `[null,null,null,null,3,null,-1,3,1]`, IsHidden: true},
+			},
+		},
+		{
+			Slug:           "min-stack",
+			Title:          "155. Min Stack",
+			Difficulty:     domain.Medium,
+			Category:       domain.CategoryAlgorithms,
+			AcceptanceRate: 55.3,
+			Submissions:    3100000,
+			Accepted:       1714300,
+			Description:    `Design a stack that supports push, pop, top, and retrieving the minimum element in constant time. MinStack() initializes the stack. push(val) pushes val onto the stack, pop() removes the element on top, top() returns the top element and getMin() returns the minimum element in the stack.`,
+			Examples:       `Input: ["MinStack","push","push","push","getMin","pop","top","getMin"], [[],[-2],[0],[-3],[],[],[],[]]\nOutput: [null,null,null,null,-3,null,0,-2]`,
+			Constraints:    `-2^31 <= val <= 2^31 - 1. pop, top and getMin will always be called on non-empty stacks.`,
+			StarterCode:    `{"javascript": "class MinStack {\n  constructor() {\n    // Your code here\n  }\n\n  push(val) {\n    // Your code here\n  }\n\n  pop() {\n    // Your code here\n  }\n\n  top() {\n    // Your code here\n  }\n\n  getMin() {\n    // Your code here\n  }\n}"}`,
+			Design: &domain.DesignSpec{
+				ClassName: "MinStack",
+				Methods: []domain.Signature{
+					{FunctionName: "push", Params: []domain.Param{{Name: "val", Type: "integer"}}, ReturnType: "void"},
+					{FunctionName: "pop", ReturnType: "void"},
+					{FunctionName: "top", ReturnType: "integer"},
+					{FunctionName: "getMin", ReturnType: "integer"},
+				},
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `class MinStack {
+  constructor() {
+    this.stack = [];
+  }
+
+  push(val) {
+    const min = this.stack.length ? Math.min(val, this.getMin()) : val;
+    this.stack.push([val, min]);
+  }
+
+  pop() {
+    this.stack.pop();
+  }
+
+  top() {
+    return this.stack[this.stack.length - 1][0];
+  }
+
+  getMin() {
+    return this.stack[this.stack.length - 1][1];
+  }
+}`,
+			},
+			TestCases: []domain.TestCase{
+				{Input: `[["MinStack","push","push","push","getMin","pop","top","getMin"],[[],[-2],[0],[-3],[],[],[],[]]]`, Expected: `[null,null,null,null,-3,null,0,-2]`, IsHidden: false},
+				{Input: `[["MinStack","push","push","getMin","pop","getMin"],[[],[0],[0],[],[],[]]]`, Expected: `[null,null,null,0,null,0]`, IsHidden: false},
+				{Input: `[["MinStack","push","push","push","top","pop","getMin","pop","getMin","pop","push","top","getMin","push","top","getMin","pop","getMin"],[[],[2147483646],[2147483646],[2147483647],[],[],[],[],[],[],[2147483647],[],[],[-2147483648],[],[],[],[]]]`, Expected: `[null,null,null,null,2147483647,null,2147483646,null,2147483646,null,null,2147483647,2147483647,null,-2147483648,-2147483648,null,2147483647]`, IsHidden: true},
+			},
+		},
+		{
+			Slug:           "implement-trie-prefix-tree",
+			Title:          "208. Implement Trie (Prefix Tree)",
+			Difficulty:     domain.Medium,
+			Category:       domain.CategoryAlgorithms,
+			AcceptanceRate: 65.4,
+			Submissions:    1900000,
+			Accepted:       1242600,
+			Description:    `A trie is a tree data structure used to efficiently store and retrieve keys in a set of strings. Trie() initializes the trie. insert(word) inserts word into the trie. search(word) returns true if word was inserted before. startsWith(prefix) returns true if a previously inserted word has the prefix prefix.`,
+			Examples:       `Input: ["Trie","insert","search","search","startsWith","insert","search"], [[],["apple"],["apple"],["app"],["app"],["app"],["app"]]\nOutput: [null,null,true,false,true,null,true]`,
+			Constraints:    `1 <= word.length, prefix.length <= 2000. word and prefix consist only of lowercase English letters. At most 3 * 10^4 calls in total will be made to insert, search, and startsWith.`,
+			StarterCode:    `{"javascript": "class Trie {\n  constructor() {\n    // Your code here\n  }\n\n  insert(word) {\n    // Your code here\n  }\n\n  search(word) {\n    // Your code here\n  }\n\n  startsWith(prefix) {\n    // Your code here\n  }\n}"}`,
+			Design: &domain.DesignSpec{
+				ClassName: "Trie",
+				Methods: []domain.Signature{
+					{FunctionName: "insert", Params: []domain.Param{{Name: "word", Type: "string"}}, ReturnType: "void"},
+					{FunctionName: "search", Params: []domain.Param{{Name: "word", Type: "string"}}, ReturnType: "boolean"},
+					{FunctionName: "startsWith", Params: []domain.Param{{Name: "prefix", Type: "string"}}, ReturnType: "boolean"},
+				},
+			},
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `class Trie {
+  constructor() {
+    this.root = { children: new Map(), word: false };
+  }
+
+  insert(word) {
+    let node = this.root;
+    for (const c of word) {
+      if (!node.children.has(c)) node.children.set(c, { children: new Map(), word: false });
+      node = node.children.get(c);
+    }
+    node.word = true;
+  }
+
+  find(prefix) {
+    let node = this.root;
+    for (const c of prefix) {
+      node = node.children.get(c);
+      if (!node) return null;
+    }
+    return node;
+  }
+
+  search(word) {
+    const node = this.find(word);
+    return node !== null && node.word;
+  }
+
+  startsWith(prefix) {
+    return this.find(prefix) !== null;
+  }
+}`,
+			},
+			TestCases: []domain.TestCase{
+				{Input: `[["Trie","insert","search","search","startsWith","insert","search"],[[],["apple"],["apple"],["app"],["app"],["app"],["app"]]]`, Expected: `[null,null,true,false,true,null,true]`, IsHidden: false},
+				{Input: `[["Trie","insert","insert","search","search","startsWith","startsWith"],[[],["ab"],["abc"],["a"],["abc"],["b"],["abc"]]]`, Expected: `[null,null,null,false,true,false,true]`, IsHidden: false},
+				{Input: `[["Trie","search","startsWith","insert","startsWith","search"],[[],["a"],["a"],["a"],["a"],["a"]]]`, Expected: `[null,false,false,null,true,true]`, IsHidden: true},
+			},
+		},
+		// Interactive problems, whose solutions talk to a judge program
+		{
+			Slug:           "guess-number-higher-or-lower",
+			Title:          "374. Guess Number Higher or Lower",
+			Difficulty:     domain.Easy,
+			Category:       domain.CategoryAlgorithms,
+			AcceptanceRate: 55.2,
+			Submissions:    1900000,
+			Accepted:       1048800,
+			Description:    `We are playing the Guess Game: the judge picks a number from 1 to n and you have to guess which one it is. Your program talks to the judge over stdin and stdout. The judge first prints n on its own line. Ask about a number num by printing "? num"; the judge replies with -1 if num is higher than the picked number, 1 if it is lower, and 0 if it is the picked number. Once you know the number, print "! num" and exit. At most 31 queries may be asked, and every line must be flushed before reading the reply.`,
+			Examples:       `Input: n = 10, pick = 6\nInteraction: judge 10, you ? 5, judge 1, you ? 8, judge -1, you ? 6, judge 0, you ! 6`,
+			Constraints:    `1 <= pick <= n <= 2^31 - 1. At most 31 queries.`,
+			StarterCode:    `{"javascript": "const readline = require('readline');\nconst rl = readline.createInterface({ input: process.stdin });\nconst lines = rl[Symbol.asyncIterator]();\nconst read = async () => (await lines.next()).value;\n\n(async () => {\n  const n = Number(await read());\n  // Ask with console.log(` + "`? ${num}`" + `) and read the reply with await read(),\n  // then answer with console.log(` + "`! ${num}`" + `)\n  rl.close();\n})();", "python": "n = int(input())\n# Ask with print(f\"? {num}\", flush=True) and read the reply with input(),\n# then answer with print(f\"! {num}\", flush=True)\n"}`,
+			Interactive: &domain.InteractiveSpec{
+				Language:   "javascript",
+				QueryLimit: 31,
+				Code: `const fs = require('fs');
+const { input, queryLimit } = JSON.parse(fs.readFileSync(3, 'utf8'));
+const { n, pick } = JSON.parse(input);
+
+const reject = (reason) => {
+  console.error(reason);
+  process.exit(1);
+};
+const send = (line) => {
+  try {
+    fs.writeSync(1, line + '\n');
+  } catch (e) {
+    reject('the solution stopped reading');
+  }
+};
+
+let pending = '';
+const chunk = Buffer.alloc(4096);
+// readLine returns the solution's next line, or null once it closed stdout
+const readLine = () => {
+  while (!pending.includes('\n')) {
+    const read = fs.readSync(0, chunk, 0, chunk.length, null);
+    if (read === 0) {
+      const rest = pending;
+      pending = '';
+      return rest === '' ? null : rest;
+    }
+    pending += chunk.toString('utf8', 0, read);
+  }
+  const end = pending.indexOf('\n');
+  const line = pending.slice(0, end);
+  pending = pending.slice(end + 1);
+  return line;
+};
+
+send(String(n));
+for (let queries = 0; ; ) {
+  const line = readLine();
+  if (line === null) reject('the solution ended without an answer');
+  const match = /^([?!])\s*(-?\d+)$/.exec(line.trim());
+  if (!match) reject('unexpected line: ' + line);
+  const num = Number(match[2]);
+  if (match[1] === '!') {
+    if (num !== pick) reject('answered ' + num + ', but the number was ' + pick);
+    console.error('guessed ' + pick + ' with ' + queries + ' queries');
+    process.exit(0);
+  }
+  if (++queries > queryLimit) reject('asked more than ' + queryLimit + ' queries');
+  send(String(pick < num ? -1 : pick > num ? 1 : 0));
+}`,
+			},
+			TestCases: []domain.TestCase{
+				{Input: `{"n":10,"pick":6}`, Expected: `6`, IsHidden: false},
+				{Input: `{"n":1,"pick":1}`, Expected: `1`, IsHidden: false},
+				{Input: `{"n":2,"pick":1}`, Expected: `1`, IsHidden: false},
+				{Input: `{"n":2147483647,"pick":2147483647}`, Expected: `2147483647`, IsHidden: true},
+				{Input: `{"n":2147483647,"pick":1}`, Expected: `1`, IsHidden: true},
+				{Input: `{"n":1000000,"pick":777777}`, Expected: `777777`, IsHidden: true},
+			},
+		},
+		// Standard input/output problems, whose solutions are full programs
+		{
+			Slug:           "range-sum-queries",
+			Title:          "Range Sum Queries",
+			Difficulty:     domain.Easy,
+			Category:       domain.CategoryAlgorithms,
+			AcceptanceRate: 61.4,
+			Submissions:    48200,
+			Accepted:       29600,
+			Description:    `Read an array of n integers and answer q queries about it. The first line of the input holds n and q, the second line the array a_1, ..., a_n, and each of the next q lines a query l r. For every query print the sum a_l + ... + a_r on its own line. Your program reads the input from stdin and writes the answers to stdout.`,
+			Examples:       `Input:\n5 3\n1 2 3 4 5\n1 5\n2 3\n4 4\nOutput:\n15\n5\n4`,
+			Constraints:    `1 <= n, q <= 2 * 10^5, -10^9 <= a_i <= 10^9, 1 <= l <= r <= n.`,
+			StarterCode:    `{"javascript": "const data = require('fs').readFileSync(0, 'utf8').split(/\\s+/).filter(Boolean).map(Number);\n// Your code here\n", "python": "import sys\n\ndata = sys.stdin.read().split()\n# Your code here\n", "cpp": "#include <bits/stdc++.h>\nusing namespace std;\n\nint main() {\n    ios::sync_with_stdio(false);\n    cin.tie(nullptr);\n    // Your code here\n    return 0;\n}\n"}`,
+			IOMode:         true,
+			Reference: &domain.ReferenceSolution{
+				Language: "javascript",
+				Code: `const data = require('fs').readFileSync(0, 'utf8').split(/\s+/).filter(Boolean).map(Number);
+let pos = 0;
+const n = data[pos++], q = data[pos++];
+const prefix = [0];
+for (let i = 0; i < n; i++) prefix.push(prefix[i] + data[pos++]);
+const out = [];
+for (let i = 0; i < q; i++) {
+  const l = data[pos++], r = data[pos++];
+  out.push(prefix[r] - prefix[l - 1]);
+}
+console.log(out.join('\n'));`,
+			},
+			TestCases: []domain.TestCase{
+				{Input: "5 3\n1 2 3 4 5\n1 5\n2 3\n4 4\n", Expected: "15\n5\n4", IsHidden: false},
+				{Input: "1 1\n-7\n1 1\n", Expected: "-7", IsHidden: false},
+				{Input: "4 2\n1000000000 1000000000 1000000000 -1000000000\n1 3\n2 4\n", Expected: "3000000000\n1000000000", IsHidden: true},
+				{Input: "6 4\n3 -1 4 -1 5 -9\n1 6\n2 2\n3 5\n6 6\n", Expected: "1\n-1\n8\n-9", IsHidden: true},
+			},
+		},
 		// Database problems
 		{
 			Slug:           "combine-two-tables",
@@ -375,7 +1518,22 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Output: firstName | lastName | city | state`,
 			Constraints:    `Use SQL to solve this problem.`,
 			StarterCode:    `{"sql": "SELECT * FROM Person"}`,
-			TestCases:      []domain.TestCase{},
+			Database: &domain.DatabaseSpec{
+				Schema: `CREATE TABLE Person (personId INT PRIMARY KEY, lastName VARCHAR(255), firstName VARCHAR(255));
+CREATE TABLE Address (addressId INT PRIMARY KEY, personId INT, city VARCHAR(255), state VARCHAR(255));`,
+			},
+			TestCases: []domain.TestCase{
+				{
+					Input:    `{"headers": {"Person": ["personId", "lastName", "firstName"], "Address": ["addressId", "personId", "city", "state"]}, "rows": {"Person": [[1, "Wang", "Allen"], [2, "Alice", "Bob"]], "Address": [[1, 2, "New York City", "New York"], [2, 3, "Leetcode", "California"]]}}`,
+					Expected: `{"headers": ["firstName", "lastName", "city", "state"], "values": [["Allen", "Wang", null, null], ["Bob", "Alice", "New York City", "New York"]]}`,
+					IsHidden: false,
+				},
+				{
+					Input:    `{"headers": {"Person": ["personId", "lastName", "firstName"], "Address": ["addressId", "personId", "city", "state"]}, "rows": {"Person": [], "Address": [[1, 1, "Paris", "Ile-de-France"]]}}`,
+					Expected: `{"headers": ["firstName", "lastName", "city", "state"], "values": []}`,
+					IsHidden: true,
+				},
+			},
 		},
 		{
 			Slug:           "second-highest-salary",
@@ -389,7 +1547,26 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Output: SecondHighestSalary`,
 			Constraints:    `Use SQL to solve this problem.`,
 			StarterCode:    `{"sql": "SELECT * FROM Employee"}`,
-			TestCases:      []domain.TestCase{},
+			Database: &domain.DatabaseSpec{
+				Schema: `CREATE TABLE Employee (id INT PRIMARY KEY, salary INT);`,
+			},
+			TestCases: []domain.TestCase{
+				{
+					Input:    `{"headers": {"Employee": ["id", "salary"]}, "rows": {"Employee": [[1, 100], [2, 200], [3, 300]]}}`,
+					Expected: `{"headers": ["SecondHighestSalary"], "values": [[200]]}`,
+					IsHidden: false,
+				},
+				{
+					Input:    `{"headers": {"Employee": ["id", "salary"]}, "rows": {"Employee": [[1, 100]]}}`,
+					Expected: `{"headers": ["SecondHighestSalary"], "values": [[null]]}`,
+					IsHidden: false,
+				},
+				{
+					Input:    `{"headers": {"Employee": ["id", "salary"]}, "rows": {"Employee": [[1, 100], [2, 100]]}}`,
+					Expected: `{"headers": ["SecondHighestSalary"], "values": [[null]]}`,
+					IsHidden: true,
+				},
+			},
 		},
 		{
 			Slug:           "employees-earning-more-than-their-managers",
@@ -403,7 +1580,16 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Output: Employee`,
 			Constraints:    `Use SQL to solve this problem.`,
 			StarterCode:    `{"sql": "SELECT * FROM Employee"}`,
-			TestCases:      []domain.TestCase{},
+			Database: &domain.DatabaseSpec{
+				Schema: `CREATE TABLE Employee (id INT PRIMARY KEY, name VARCHAR(255), salary INT, managerId INT);`,
+			},
+			TestCases: []domain.TestCase{
+				{
+					Input:    `{"headers": {"Employee": ["id", "name", "salary", "managerId"]}, "rows": {"Employee": [[1, "Joe", 70000, 3], [2, "Henry", 80000, 4], [3, "Sam", 60000, null], [4, "Max", 90000, null]]}}`,
+					Expected: `{"headers": ["Employee"], "values": [["Joe"]]}`,
+					IsHidden: false,
+				},
+			},
 		},
 		// Shell problems
 		{
@@ -418,7 +1604,18 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Output: the 4\nis 3\nsunny 2`,
 			Constraints:    `Use bash to solve this problem.`,
 			StarterCode:    `{"bash": "# Read from the file words.txt and output the word frequency list to stdout."}`,
-			TestCases:      []domain.TestCase{},
+			TestCases: []domain.TestCase{
+				{
+					Files:    map[string]string{"words.txt": "the day is sunny the the\nthe sunny is is\n"},
+					Expected: "the 4\nis 3\nsunny 2\nday 1",
+					IsHidden: false,
+				},
+				{
+					Files:    map[string]string{"words.txt": "a  b   a\n\nc a b\n"},
+					Expected: "a 3\nb 2\nc 1",
+					IsHidden: true,
+				},
+			},
 		},
 		{
 			Slug:           "valid-phone-numbers",
@@ -432,7 +1629,66 @@ func SeedProblems() []domain.Problem {
 			Examples:       `Output: 987-123-4567`,
 			Constraints:    `Use bash to solve this problem.`,
 			StarterCode:    `{"bash": "# Read from the file file.txt and output all valid phone numbers to stdout."}`,
-			TestCases:      []domain.TestCase{},
+			TestCases: []domain.TestCase{
+				{
+					Files:    map[string]string{"file.txt": "987-123-4567\n123 456 7890\n(123) 456-7890\n"},
+					Expected: "987-123-4567\n(123) 456-7890",
+					IsHidden: false,
+				},
+				{
+					Files:    map[string]string{"file.txt": "123-456-78901\n(001) 345-0000\n0(01) 345-0000\n"},
+					Expected: "(001) 345-0000",
+					IsHidden: true,
+				},
+			},
+		},
+		// Concurrency problems
+		{
+			Slug:           "print-in-order",
+			Title:          "1114. Print in Order",
+			Difficulty:     domain.Easy,
+			Category:       domain.CategoryConcurrency,
+			AcceptanceRate: 70.2,
+			Submissions:    600000,
+			Accepted:       421200,
+			Description:    `The same instance of Foo will be passed to three different threads. Thread A will call first(), thread B will call second(), and thread C will call third(). Design a mechanism to ensure that second() is executed after first(), and third() is executed after second().`,
+			Examples:       `Input: nums = [1,3,2]\nOutput: "firstsecondthird"`,
+			Constraints:    `nums is a permutation of [1, 2, 3].`,
+			StarterCode:    `{"python": "class Foo:\n    def __init__(self):\n        pass\n\n    def first(self, printFirst):\n        printFirst()\n\n    def second(self, printSecond):\n        printSecond()\n\n    def third(self, printThird):\n        printThird()", "go": "type Foo struct {\n}\n\nfunc NewFoo() *Foo {\n\treturn &Foo{}\n}\n\nfunc (f *Foo) First(printFirst func()) {\n\tprintFirst()\n}\n\nfunc (f *Foo) Second(printSecond func()) {\n\tprintSecond()\n}\n\nfunc (f *Foo) Third(printThird func()) {\n\tprintThird()\n}"}`,
+			Concurrency: &domain.ConcurrencySpec{
+				ClassName: "Foo",
+				Tokens:    map[string]string{"first": "first", "second": "second", "third": "third"},
+				Runs:      20,
+			},
+			TestCases: []domain.TestCase{
+				{Input: `["first","second","third"]`, Expected: `firstsecondthird`, IsHidden: false},
+				{Input: `["first","third","second"]`, Expected: `firstsecondthird`, IsHidden: false},
+				{Input: `["third","second","first"]`, Expected: `firstsecondthird`, IsHidden: true},
+			},
+		},
+		{
+			Slug:           "building-h2o",
+			Title:          "1117. Building H2O",
+			Difficulty:     domain.Medium,
+			Category:       domain.CategoryConcurrency,
+			AcceptanceRate: 55.4,
+			Submissions:    250000,
+			Accepted:       138500,
+			Description:    `There are two kinds of threads: oxygen and hydrogen. Write synchronization code so that threads pass the barrier in groups of three, forming water molecules of two hydrogen and one oxygen.`,
+			Examples:       `Input: water = "HOH"\nOutput: "HHO"`,
+			Constraints:    `3 * n == water.length, 1 <= n <= 20`,
+			StarterCode:    `{"python": "class H2O:\n    def __init__(self):\n        pass\n\n    def hydrogen(self, releaseHydrogen):\n        releaseHydrogen()\n\n    def oxygen(self, releaseOxygen):\n        releaseOxygen()", "go": "type H2O struct {\n}\n\nfunc NewH2O() *H2O {\n\treturn &H2O{}\n}\n\nfunc (h *H2O) Hydrogen(releaseHydrogen func()) {\n\treleaseHydrogen()\n}\n\nfunc (h *H2O) Oxygen(releaseOxygen func()) {\n\treleaseOxygen()\n}"}`,
+			Concurrency: &domain.ConcurrencySpec{
+				ClassName: "H2O",
+				Tokens:    map[string]string{"hydrogen": "H", "oxygen": "O"},
+				Runs:      20,
+				Invariant: "HHO",
+			},
+			TestCases: []domain.TestCase{
+				{Input: `["hydrogen","oxygen","hydrogen"]`, Expected: `HHO`, IsHidden: false},
+				{Input: `["oxygen","oxygen","hydrogen","hydrogen","hydrogen","hydrogen"]`, Expected: `HHOHHO`, IsHidden: false},
+				{Input: `["oxygen","oxygen","oxygen","hydrogen","hydrogen","hydrogen","hydrogen","hydrogen","hydrogen"]`, Expected: `HHOHHOHHO`, IsHidden: true},
+			},
 		},
 	}
 }
@@ -4,6 +4,8 @@ package sqlite
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -16,12 +18,18 @@ type SubmissionModel struct {
 	ProblemID uint
 	Language  string
 	Code      string
-	Status    string
+	Status    string `gorm:"index"`
 	Runtime   int
 	Memory    int
 	Output    string
+	Error     string
 	Results   string // JSON-encoded results
+	Attempts  int
 	CreatedAt int64
+
+	// The judge holding a running submission, and until when (Unix milliseconds)
+	LeaseOwner     string
+	LeaseExpiresAt int64 `gorm:"index"`
 }
 
 // TableName returns the table name
@@ -60,6 +68,94 @@ func (r *SubmissionRepository) Update(ctx context.Context, submission *domain.Su
 	return r.db.WithContext(ctx).Save(&model).Error
 }
 
+// Claim leases the oldest pending submission to owner. The single UPDATE
+// makes the claim atomic across workers and processes.
+func (r *SubmissionRepository) Claim(ctx context.Context, owner string, lease time.Duration) (*domain.Submission, error) {
+	var models []SubmissionModel
+	err := r.db.WithContext(ctx).Raw(
+		`UPDATE submissions SET status = ?, lease_owner = ?, lease_expires_at = ?, attempts = attempts + 1
+		WHERE id = (SELECT id FROM submissions WHERE status = ? ORDER BY created_at, rowid LIMIT 1)
+		RETURNING *`,
+		domain.StatusRunning, owner, leaseDeadline(lease), domain.StatusPending,
+	).Scan(&models).Error
+	if err != nil || len(models) == 0 {
+		return nil, err
+	}
+
+	submission := toDomainSubmission(models[0])
+	return &submission, nil
+}
+
+// Renew extends owner's lease on a running submission
+func (r *SubmissionRepository) Renew(ctx context.Context, id, owner string, lease time.Duration) error {
+	result := r.db.WithContext(ctx).Model(&SubmissionModel{}).
+		Where("id = ? AND status = ? AND lease_owner = ?", id, domain.StatusRunning, owner).
+		Update("lease_expires_at", leaseDeadline(lease))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrLeaseLost
+	}
+	return nil
+}
+
+// Complete stores a verdict if owner still holds the submission's lease
+func (r *SubmissionRepository) Complete(ctx context.Context, submission *domain.Submission, owner string) error {
+	model := toModelSubmission(*submission)
+	// Select writes the cleared lease columns too
+	result := r.db.WithContext(ctx).Model(&SubmissionModel{}).
+		Where("id = ? AND status = ? AND lease_owner = ?", submission.ID, domain.StatusRunning, owner).
+		Select("*").Omit("id", "created_at").
+		Updates(&model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrLeaseLost
+	}
+	return nil
+}
+
+// RequeueExpired returns running submissions with expired leases to
+// Pending, failing those that have used up their attempts
+func (r *SubmissionRepository) RequeueExpired(ctx context.Context, maxAttempts int) (requeued, failed int64, err error) {
+	now := time.Now().UnixMilli()
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&SubmissionModel{}).
+			Where("status = ? AND lease_expires_at < ? AND attempts >= ?", domain.StatusRunning, now, maxAttempts).
+			Updates(map[string]interface{}{
+				"status":           domain.StatusInternal,
+				"error":            fmt.Sprintf("judging did not finish in %d attempts", maxAttempts),
+				"lease_owner":      "",
+				"lease_expires_at": 0,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		failed = result.RowsAffected
+
+		result = tx.Model(&SubmissionModel{}).
+			Where("status = ? AND lease_expires_at < ?", domain.StatusRunning, now).
+			Updates(map[string]interface{}{
+				"status":           domain.StatusPending,
+				"lease_owner":      "",
+				"lease_expires_at": 0,
+			})
+		requeued = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return requeued, failed, nil
+}
+
+// leaseDeadline returns when a lease taken now expires, in Unix milliseconds
+func leaseDeadline(lease time.Duration) int64 {
+	return time.Now().Add(lease).UnixMilli()
+}
+
 // --- Mappers ---
 
 func toDomainSubmission(m SubmissionModel) domain.Submission {
@@ -77,7 +173,10 @@ func toDomainSubmission(m SubmissionModel) domain.Submission {
 		Runtime:   m.Runtime,
 		Memory:    m.Memory,
 		Output:    m.Output,
+		Error:     m.Error,
 		Results:   results,
+		Attempts:  m.Attempts,
+		CreatedAt: time.Unix(m.CreatedAt, 0),
 	}
 }
 
@@ -93,7 +192,9 @@ func toModelSubmission(s domain.Submission) SubmissionModel {
 		Runtime:   s.Runtime,
 		Memory:    s.Memory,
 		Output:    s.Output,
+		Error:     s.Error,
 		Results:   string(resultsJSON),
+		Attempts:  s.Attempts,
 		CreatedAt: s.CreatedAt.Unix(),
 	}
 }
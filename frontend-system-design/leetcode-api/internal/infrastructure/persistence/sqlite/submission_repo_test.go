@@ -0,0 +1,161 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	domain "leetcode-api/internal/domain/submission"
+)
+
+// newTestRepository returns a repository backed by a fresh database file
+func newTestRepository(t *testing.T) *SubmissionRepository {
+	t.Helper()
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewSubmissionRepository(db)
+}
+
+// createPending stores a pending submission created age ago
+func createPending(t *testing.T, repo *SubmissionRepository, id string, age time.Duration) {
+	t.Helper()
+	submission := domain.NewSubmission(id, 1, "python", "pass")
+	submission.CreatedAt = time.Now().Add(-age)
+	if err := repo.Create(context.Background(), submission); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClaim(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	if claimed, err := repo.Claim(ctx, "judge-1", time.Minute); err != nil || claimed != nil {
+		t.Fatalf("Claim() on an empty queue = %v, %v; want nil", claimed, err)
+	}
+
+	createPending(t, repo, "newer", time.Second)
+	createPending(t, repo, "older", time.Minute)
+	for _, want := range []string{"older", "newer"} {
+		claimed, err := repo.Claim(ctx, "judge-1", time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if claimed == nil || claimed.ID != want {
+			t.Fatalf("Claim() = %v, want %s", claimed, want)
+		}
+		if claimed.Status != domain.StatusRunning || claimed.Attempts != 1 {
+			t.Errorf("claimed %s is %s after %d attempts, want Running after 1", claimed.ID, claimed.Status, claimed.Attempts)
+		}
+	}
+
+	if claimed, err := repo.Claim(ctx, "judge-2", time.Minute); err != nil || claimed != nil {
+		t.Errorf("Claim() with every submission running = %v, %v; want nil", claimed, err)
+	}
+}
+
+func TestLeaseOwnership(t *testing.T) {
+	tests := []struct {
+		name  string
+		owner string
+		lease time.Duration // of the claim
+		// requeue runs RequeueExpired before the owner acts
+		requeue bool
+		wantErr error
+	}{
+		{name: "owner", owner: "judge-1", lease: time.Minute},
+		{name: "other judge", owner: "judge-2", lease: time.Minute, wantErr: domain.ErrLeaseLost},
+		{name: "expired but not requeued", owner: "judge-1", lease: -time.Second},
+		{name: "requeued", owner: "judge-1", lease: -time.Second, requeue: true, wantErr: domain.ErrLeaseLost},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := newTestRepository(t)
+			createPending(t, repo, "s1", 0)
+			claimed, err := repo.Claim(ctx, "judge-1", tt.lease)
+			if err != nil || claimed == nil {
+				t.Fatalf("Claim() = %v, %v", claimed, err)
+			}
+			if tt.requeue {
+				if _, _, err := repo.RequeueExpired(ctx, 3); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := repo.Renew(ctx, "s1", tt.owner, time.Minute); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Renew() error = %v, want %v", err, tt.wantErr)
+			}
+			claimed.SetResults([]domain.TestResult{{Status: domain.StatusAccepted, Passed: true}})
+			if err := repo.Complete(ctx, claimed, tt.owner); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Complete() error = %v, want %v", err, tt.wantErr)
+			}
+
+			stored, err := repo.FindByID(ctx, "s1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantStatus := domain.StatusAccepted
+			switch {
+			case tt.requeue:
+				wantStatus = domain.StatusPending
+			case tt.wantErr != nil:
+				wantStatus = domain.StatusRunning
+			}
+			if stored.Status != wantStatus {
+				t.Errorf("stored status = %s, want %s", stored.Status, wantStatus)
+			}
+		})
+	}
+}
+
+func TestRequeueExpired(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	const maxAttempts = 3
+
+	createPending(t, repo, "live", time.Minute)
+	createPending(t, repo, "expired", time.Second)
+	if _, err := repo.Claim(ctx, "judge-1", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each expired claim uses one attempt, until the last one fails the submission
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		claimed, err := repo.Claim(ctx, "judge-2", -time.Second)
+		if err != nil || claimed == nil || claimed.ID != "expired" {
+			t.Fatalf("attempt %d: Claim() = %v, %v; want expired", attempt, claimed, err)
+		}
+		requeued, failed, err := repo.RequeueExpired(ctx, maxAttempts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wantRequeued, wantFailed, wantStatus := int64(1), int64(0), domain.StatusPending
+		if attempt == maxAttempts {
+			wantRequeued, wantFailed, wantStatus = 0, 1, domain.StatusInternal
+		}
+		if requeued != wantRequeued || failed != wantFailed {
+			t.Errorf("attempt %d: RequeueExpired() = %d requeued, %d failed; want %d, %d", attempt, requeued, failed, wantRequeued, wantFailed)
+		}
+		stored, err := repo.FindByID(ctx, "expired")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.Status != wantStatus || stored.Attempts != attempt {
+			t.Errorf("attempt %d: stored %s after %d attempts, want %s", attempt, stored.Status, stored.Attempts, wantStatus)
+		}
+	}
+
+	live, err := repo.FindByID(ctx, "live")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if live.Status != domain.StatusRunning {
+		t.Errorf("submission with a live lease is %s, want Running", live.Status)
+	}
+}
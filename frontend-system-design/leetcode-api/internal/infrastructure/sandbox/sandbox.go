@@ -0,0 +1,60 @@
+// Package sandbox confines untrusted processes using Linux namespaces,
+// resource limits, a read-only filesystem and a seccomp syscall filter.
+//
+// Confined commands are started through a helper: the current binary is
+// re-executed with a special argv[0], sets up the confinement from inside
+// the new namespaces and then execs the real command. Binaries that wrap
+// commands must therefore call Init at the very start of main.
+package sandbox
+
+import (
+	"os"
+	"os/exec"
+	"time"
+)
+
+// Policy declares the resources a confined command may use.
+// Zero values leave the corresponding limit unset.
+type Policy struct {
+	CPUTime      time.Duration `json:"cpuTime"`
+	AddressSpace uint64        `json:"addressSpace"` // bytes of virtual memory
+	FileSize     uint64        `json:"fileSize"`     // bytes per written file
+	Processes    uint64        `json:"processes"`    // processes and threads
+	OpenFiles    uint64        `json:"openFiles"`
+}
+
+// Sandbox wraps commands so they run confined
+type Sandbox struct {
+	uid int // host user the confined command runs as
+	gid int // host group the confined command runs as
+}
+
+// Mount makes a host directory available to a confined command, such as a
+// program's build output or a compiler's cache
+type Mount struct {
+	Path     string
+	Writable bool
+}
+
+// Wrap rewrites cmd so that it runs confined under policy. The command's
+// Dir is writable and also serves as its TMPDIR; everything else is
+// read-only unless mounted writable. The host's temporary directory is
+// replaced by an empty one holding only Dir and the mounts, so commands
+// cannot see each other's files.
+// Wrap must be called after Dir is set and before the command starts.
+func (s *Sandbox) Wrap(cmd *exec.Cmd, policy Policy, mounts ...Mount) error {
+	return s.wrap(cmd, policy, mounts)
+}
+
+// Environ returns the environment confined commands get by default: only
+// the variables language runtimes need, so server configuration and
+// secrets never reach submissions
+func Environ() []string {
+	var env []string
+	for _, key := range []string{"PATH", "HOME", "LANG"} {
+		if v, ok := os.LookupEnv(key); ok {
+			env = append(env, key+"="+v)
+		}
+	}
+	return env
+}
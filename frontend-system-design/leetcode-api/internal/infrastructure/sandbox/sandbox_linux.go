@@ -0,0 +1,401 @@
+//go:build linux
+
+// Package sandbox provides the Linux implementation of process confinement.
+package sandbox
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// helperArg0 marks a re-executed process as the sandbox helper
+	helperArg0 = "leetcode-sandbox-init"
+	// probeArg0 marks the no-op command New runs to check confinement works
+	probeArg0 = "leetcode-sandbox-probe"
+	// helperExitCode is returned when the helper fails before exec
+	helperExitCode = 125
+	// helperErrorPrefix starts the message the helper leaves on stderr when it fails
+	helperErrorPrefix = "sandbox: "
+	// nobody is the unprivileged host user confined commands use when the server runs as root
+	nobody = 65534
+)
+
+// helperSpec is passed from the parent to the helper on the command line
+type helperSpec struct {
+	Policy       Policy   `json:"policy"`
+	WritableDir  string   `json:"writableDir"`
+	WritableDirs []string `json:"writableDirs,omitempty"` // mounted writable besides WritableDir
+	ReadOnlyDirs []string `json:"readOnlyDirs,omitempty"`
+	TempDir      string   `json:"tempDir"` // the host's, hidden from the command
+}
+
+// Init turns the process into the sandbox helper when it was started as one.
+// It returns immediately in any other process.
+func Init() {
+	if len(os.Args) == 0 {
+		return
+	}
+	switch os.Args[0] {
+	case probeArg0:
+		os.Exit(0)
+	case helperArg0:
+		if err := runHelper(os.Args[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "%s%v\n", helperErrorPrefix, err)
+		}
+		os.Exit(helperExitCode)
+	}
+}
+
+// HelperFailed reports whether a wrapped command that exited with state
+// failed in the helper before the command itself started, judging by the
+// helper's exit code and the message it leaves at the start of stderr
+func (s *Sandbox) HelperFailed(state *os.ProcessState, stderr string) bool {
+	return state != nil && state.ExitCode() == helperExitCode && strings.HasPrefix(stderr, helperErrorPrefix)
+}
+
+// DefaultIDs returns the host user and group for confined commands: the
+// current ones, or nobody when running as root so submissions cannot read
+// files that only root can access.
+func DefaultIDs() (int, int) {
+	if os.Getuid() == 0 {
+		return nobody, nobody
+	}
+	return os.Getuid(), os.Getgid()
+}
+
+// New returns a sandbox running confined commands as uid/gid on the host.
+// It fails if this host cannot create the namespaces the sandbox needs.
+func New(uid, gid int) (*Sandbox, error) {
+	s := &Sandbox{uid: uid, gid: gid}
+
+	dir, err := os.MkdirTemp("", "sandbox-probe-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	cmd := &exec.Cmd{Path: "/proc/self/exe", Args: []string{probeArg0}, Dir: dir}
+	if err := s.wrap(cmd, Policy{CPUTime: time.Second}, nil); err != nil {
+		return nil, err
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("sandbox probe failed: %v %s", err, strings.TrimSpace(string(out)))
+	}
+	return s, nil
+}
+
+func (s *Sandbox) wrap(cmd *exec.Cmd, policy Policy, mounts []Mount) error {
+	if cmd.Err != nil {
+		return cmd.Err
+	}
+	if cmd.Dir == "" {
+		return fmt.Errorf("sandboxed commands need a working directory")
+	}
+
+	dir, err := realPath(cmd.Dir)
+	if err != nil {
+		return err
+	}
+	if err := s.own(dir); err != nil {
+		return err
+	}
+
+	spec := helperSpec{Policy: policy, WritableDir: dir}
+	if spec.TempDir, err = realPath(os.TempDir()); err != nil {
+		return err
+	}
+	for _, m := range mounts {
+		if m.Path == "" {
+			continue
+		}
+		path, err := realPath(m.Path)
+		if err != nil {
+			return err
+		}
+		if !m.Writable {
+			spec.ReadOnlyDirs = append(spec.ReadOnlyDirs, path)
+			continue
+		}
+		if err := s.own(path); err != nil {
+			return err
+		}
+		spec.WritableDirs = append(spec.WritableDirs, path)
+	}
+	encoded, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Env == nil {
+		cmd.Env = Environ()
+	}
+	cmd.Env = append(cmd.Env, "TMPDIR="+dir)
+
+	cmd.Args = append([]string{helperArg0, string(encoded), cmd.Path}, cmd.Args...)
+	cmd.Path = "/proc/self/exe"
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags: syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS | syscall.CLONE_NEWPID |
+			syscall.CLONE_NEWNET | syscall.CLONE_NEWIPC | syscall.CLONE_NEWUTS,
+		UidMappings:                []syscall.SysProcIDMap{{ContainerID: 0, HostID: s.uid, Size: 1}},
+		GidMappings:                []syscall.SysProcIDMap{{ContainerID: 0, HostID: s.gid, Size: 1}},
+		GidMappingsEnableSetgroups: false,
+		// Switch to the namespace's root so the helper keeps its capabilities
+		// across exec even when the host user differs from ours
+		Credential: &syscall.Credential{Uid: 0, Gid: 0, NoSetGroups: true},
+		Pdeathsig:  syscall.SIGKILL,
+	}
+	return nil
+}
+
+// realPath returns the absolute path of dir with symlinks resolved, as the
+// helper compares it with mount points
+func realPath(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(dir)
+}
+
+// own hands a writable directory to the host user confined commands run as
+func (s *Sandbox) own(dir string) error {
+	if s.uid == os.Getuid() {
+		return nil
+	}
+	return os.Chown(dir, s.uid, s.gid)
+}
+
+// runHelper confines the current process and execs the target command.
+// It runs as root of the fresh user namespace created by wrap.
+func runHelper(args []string) error {
+	if len(args) < 3 {
+		return fmt.Errorf("invalid helper arguments")
+	}
+
+	// Seccomp filters and capability sets are per thread
+	runtime.LockOSThread()
+
+	var spec helperSpec
+	if err := json.Unmarshal([]byte(args[0]), &spec); err != nil {
+		return fmt.Errorf("invalid helper spec: %w", err)
+	}
+	path, argv := args[1], args[2:]
+
+	if err := setupFilesystem(spec); err != nil {
+		return fmt.Errorf("filesystem: %w", err)
+	}
+	if err := setRlimits(spec.Policy); err != nil {
+		return fmt.Errorf("rlimits: %w", err)
+	}
+	if err := dropCapabilities(); err != nil {
+		return fmt.Errorf("capabilities: %w", err)
+	}
+	if err := installSeccomp(); err != nil {
+		return fmt.Errorf("seccomp: %w", err)
+	}
+
+	if err := syscall.Exec(path, argv, os.Environ()); err != nil {
+		return fmt.Errorf("exec %s: %w", path, err)
+	}
+	return nil
+}
+
+// setupFilesystem replaces the host's temporary directory with an empty
+// tmpfs holding only the spec's directories, makes every mount read-only
+// except the writable directories and mounts a /proc that only shows the
+// sandbox's own processes
+func setupFilesystem(spec helperSpec) error {
+	writable := append([]string{spec.WritableDir}, spec.WritableDirs...)
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+		return err
+	}
+	if err := privateTempDir(spec.TempDir, append(writable, spec.ReadOnlyDirs...)); err != nil {
+		return fmt.Errorf("temporary directory: %w", err)
+	}
+	// Writable directories become mount points of their own, which the
+	// read-only remount below skips
+	keep := make(map[string]bool)
+	for _, dir := range writable {
+		if err := unix.Mount(dir, dir, "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+			return err
+		}
+		keep[dir] = true
+	}
+
+	mounts, err := readMounts()
+	if err != nil {
+		return err
+	}
+	for _, m := range mounts {
+		if keep[m.point] || m.flags&unix.MS_RDONLY != 0 {
+			continue
+		}
+		flags := uintptr(unix.MS_BIND | unix.MS_REMOUNT | unix.MS_RDONLY | m.flags)
+		if err := unix.Mount("", m.point, "", flags, ""); err != nil {
+			// Mounts hidden from the new namespace cannot be remounted or reached
+			if m.point == "/" {
+				return fmt.Errorf("remount %s read-only: %w", m.point, err)
+			}
+		}
+	}
+
+	// Some container runtimes forbid fresh proc mounts; the old one is read-only by now
+	unix.Mount("proc", "/proc", "proc", unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC|unix.MS_RDONLY, "")
+
+	// The inherited working directory still refers to the directory under the bind mount
+	return unix.Chdir(spec.WritableDir)
+}
+
+// privateTempDir mounts an empty tmpfs over tempDir and binds the given
+// directories back into it at their own paths. Directories outside tempDir
+// stay where they are.
+func privateTempDir(tempDir string, dirs []string) error {
+	// The directories are about to be hidden, so they are kept open and
+	// bound from the descriptors
+	kept := make(map[string]int)
+	defer func() {
+		for _, fd := range kept {
+			unix.Close(fd)
+		}
+	}()
+	for _, dir := range dirs {
+		rel, err := filepath.Rel(tempDir, dir)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		fd, err := unix.Open(dir, unix.O_PATH|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+		if err != nil {
+			return err
+		}
+		kept[dir] = fd
+	}
+
+	if err := unix.Mount("tmpfs", tempDir, "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, "mode=755,size=1m"); err != nil {
+		return err
+	}
+	for dir, fd := range kept {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		if err := unix.Mount(fmt.Sprintf("/proc/self/fd/%d", fd), dir, "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mount is a mount point with the per-mount flags that must be preserved on remount
+type mount struct {
+	point string
+	flags uintptr
+}
+
+// readMounts parses /proc/self/mountinfo
+func readMounts() ([]mount, error) {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	optionFlags := map[string]uintptr{
+		"ro":          unix.MS_RDONLY,
+		"nosuid":      unix.MS_NOSUID,
+		"nodev":       unix.MS_NODEV,
+		"noexec":      unix.MS_NOEXEC,
+		"noatime":     unix.MS_NOATIME,
+		"nodiratime":  unix.MS_NODIRATIME,
+		"relatime":    unix.MS_RELATIME,
+		"strictatime": unix.MS_STRICTATIME,
+	}
+
+	var mounts []mount
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 {
+			continue
+		}
+		m := mount{point: unescapeMountPath(fields[4])}
+		for _, opt := range strings.Split(fields[5], ",") {
+			m.flags |= optionFlags[opt]
+		}
+		mounts = append(mounts, m)
+	}
+	return mounts, scanner.Err()
+}
+
+// unescapeMountPath decodes the octal escapes mountinfo uses for whitespace
+func unescapeMountPath(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if v, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// setRlimits applies the policy's resource limits and disables core dumps
+func setRlimits(p Policy) error {
+	limits := map[int]uint64{unix.RLIMIT_CORE: 0}
+	if p.CPUTime > 0 {
+		// RLIMIT_CPU has one-second granularity; round up so short limits still apply
+		limits[unix.RLIMIT_CPU] = uint64((p.CPUTime + time.Second - 1) / time.Second)
+	}
+	if p.AddressSpace > 0 {
+		limits[unix.RLIMIT_AS] = p.AddressSpace
+	}
+	if p.FileSize > 0 {
+		limits[unix.RLIMIT_FSIZE] = p.FileSize
+	}
+	if p.Processes > 0 {
+		limits[unix.RLIMIT_NPROC] = p.Processes
+	}
+	if p.OpenFiles > 0 {
+		limits[unix.RLIMIT_NOFILE] = p.OpenFiles
+	}
+
+	for resource, value := range limits {
+		if err := unix.Setrlimit(resource, &unix.Rlimit{Cur: value, Max: value}); err != nil {
+			return fmt.Errorf("resource %d: %w", resource, err)
+		}
+	}
+	return nil
+}
+
+// dropCapabilities empties the bounding set so the target, although it is
+// root inside the user namespace, execs without any capabilities
+func dropCapabilities() error {
+	data, err := os.ReadFile("/proc/sys/kernel/cap_last_cap")
+	if err != nil {
+		return err
+	}
+	last, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return err
+	}
+	for c := 0; c <= last; c++ {
+		if err := unix.Prctl(unix.PR_CAPBSET_DROP, uintptr(c), 0, 0, 0); err != nil {
+			return err
+		}
+	}
+	return unix.Prctl(unix.PR_CAP_AMBIENT, unix.PR_CAP_AMBIENT_CLEAR_ALL, 0, 0, 0)
+}
@@ -0,0 +1,34 @@
+//go:build !linux
+
+// Package sandbox provides a stub for platforms without namespace support.
+package sandbox
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+var errUnsupported = errors.New("sandboxing requires Linux")
+
+// Init is a no-op outside Linux
+func Init() {}
+
+// DefaultIDs returns the current user and group
+func DefaultIDs() (int, int) {
+	return os.Getuid(), os.Getgid()
+}
+
+// New always fails outside Linux
+func New(uid, gid int) (*Sandbox, error) {
+	return nil, errUnsupported
+}
+
+// HelperFailed is always false outside Linux, where nothing is wrapped
+func (s *Sandbox) HelperFailed(state *os.ProcessState, stderr string) bool {
+	return false
+}
+
+func (s *Sandbox) wrap(cmd *exec.Cmd, policy Policy, mounts []Mount) error {
+	return errUnsupported
+}
@@ -0,0 +1,11 @@
+//go:build linux
+
+// Package sandbox provides the amd64 seccomp parameters.
+package sandbox
+
+import "golang.org/x/sys/unix"
+
+const auditArch = unix.AUDIT_ARCH_X86_64
+
+// archDeniedSyscalls grant raw I/O port access on x86
+var archDeniedSyscalls = []uintptr{unix.SYS_IOPL, unix.SYS_IOPERM}
@@ -0,0 +1,10 @@
+//go:build linux
+
+// Package sandbox provides the arm64 seccomp parameters.
+package sandbox
+
+import "golang.org/x/sys/unix"
+
+const auditArch = unix.AUDIT_ARCH_AARCH64
+
+var archDeniedSyscalls []uintptr
@@ -0,0 +1,107 @@
+//go:build linux
+
+// Package sandbox provides the seccomp syscall filter for confined processes.
+package sandbox
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Values from linux/seccomp.h that x/sys/unix does not export
+const (
+	seccompSetModeFilter   = 1
+	seccompFilterFlagTsync = 1
+	seccompRetKillProcess  = 0x80000000
+	seccompRetErrno        = 0x00050000
+	seccompRetAllow        = 0x7fff0000
+
+	// offsets into struct seccomp_data
+	seccompDataNr   = 0
+	seccompDataArch = 4
+	seccompDataArg0 = 16
+)
+
+// namespaceCloneFlags would let clone escape the sandbox's namespaces
+const namespaceCloneFlags = unix.CLONE_NEWNS | unix.CLONE_NEWUTS | unix.CLONE_NEWIPC |
+	unix.CLONE_NEWUSER | unix.CLONE_NEWPID | unix.CLONE_NEWNET | unix.CLONE_NEWCGROUP
+
+// deniedSyscalls fail with EPERM: they manipulate other processes, mounts,
+// namespaces, kernel state or the clock, none of which solutions need
+var deniedSyscalls = append([]uintptr{
+	unix.SYS_PTRACE, unix.SYS_PROCESS_VM_READV, unix.SYS_PROCESS_VM_WRITEV,
+	unix.SYS_MOUNT, unix.SYS_UMOUNT2, unix.SYS_PIVOT_ROOT, unix.SYS_CHROOT,
+	unix.SYS_SETNS, unix.SYS_UNSHARE,
+	unix.SYS_SWAPON, unix.SYS_SWAPOFF, unix.SYS_REBOOT, unix.SYS_ACCT, unix.SYS_QUOTACTL,
+	unix.SYS_KEXEC_LOAD, unix.SYS_KEXEC_FILE_LOAD,
+	unix.SYS_INIT_MODULE, unix.SYS_FINIT_MODULE, unix.SYS_DELETE_MODULE,
+	unix.SYS_KEYCTL, unix.SYS_ADD_KEY, unix.SYS_REQUEST_KEY,
+	unix.SYS_BPF, unix.SYS_PERF_EVENT_OPEN, unix.SYS_USERFAULTFD,
+	unix.SYS_OPEN_BY_HANDLE_AT, unix.SYS_NAME_TO_HANDLE_AT,
+	unix.SYS_SETTIMEOFDAY, unix.SYS_CLOCK_SETTIME, unix.SYS_ADJTIMEX,
+}, archDeniedSyscalls...)
+
+// seccompFilter builds the BPF program. Syscalls from a foreign ABI kill the
+// process, denied syscalls return EPERM, clone3 returns ENOSYS so libc falls
+// back to clone, and clone is refused when it asks for new namespaces.
+func seccompFilter() []unix.SockFilter {
+	stmt := func(code uint16, k uint32) unix.SockFilter {
+		return unix.SockFilter{Code: code, K: k}
+	}
+	jump := func(code uint16, k uint32, jt, jf uint8) unix.SockFilter {
+		return unix.SockFilter{Code: code, Jt: jt, Jf: jf, K: k}
+	}
+	const (
+		load = unix.BPF_LD | unix.BPF_W | unix.BPF_ABS
+		jeq  = unix.BPF_JMP | unix.BPF_JEQ | unix.BPF_K
+		jge  = unix.BPF_JMP | unix.BPF_JGE | unix.BPF_K
+		jset = unix.BPF_JMP | unix.BPF_JSET | unix.BPF_K
+		ret  = unix.BPF_RET | unix.BPF_K
+	)
+
+	filter := []unix.SockFilter{
+		stmt(load, seccompDataArch),
+		jump(jeq, auditArch, 1, 0),
+		stmt(ret, seccompRetKillProcess),
+		stmt(load, seccompDataNr),
+		// x32 syscalls on amd64 share the arch value but set this bit
+		jump(jge, 0x40000000, 0, 1),
+		stmt(ret, seccompRetKillProcess),
+	}
+	for _, nr := range deniedSyscalls {
+		filter = append(filter,
+			jump(jeq, uint32(nr), 0, 1),
+			stmt(ret, seccompRetErrno|uint32(unix.EPERM)),
+		)
+	}
+	filter = append(filter,
+		jump(jeq, unix.SYS_CLONE3, 0, 1),
+		stmt(ret, seccompRetErrno|uint32(unix.ENOSYS)),
+		jump(jeq, unix.SYS_CLONE, 0, 3),
+		stmt(load, seccompDataArg0),
+		jump(jset, namespaceCloneFlags, 0, 1),
+		stmt(ret, seccompRetErrno|uint32(unix.EPERM)),
+		stmt(ret, seccompRetAllow),
+	)
+	return filter
+}
+
+// installSeccomp applies the filter to every thread of the process
+func installSeccomp() error {
+	if auditArch == 0 {
+		return fmt.Errorf("no seccomp filter for this architecture")
+	}
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return err
+	}
+
+	filter := seccompFilter()
+	prog := unix.SockFprog{Len: uint16(len(filter)), Filter: &filter[0]}
+	if _, _, errno := unix.Syscall(unix.SYS_SECCOMP, seccompSetModeFilter, seccompFilterFlagTsync,
+		uintptr(unsafe.Pointer(&prog))); errno != 0 {
+		return errno
+	}
+	return nil
+}
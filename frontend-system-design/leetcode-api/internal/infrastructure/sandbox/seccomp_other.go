@@ -0,0 +1,9 @@
+//go:build linux && !amd64 && !arm64
+
+// Package sandbox disables seccomp on architectures without a filter.
+package sandbox
+
+// auditArch of zero makes installSeccomp fail, so New reports the sandbox as unavailable
+const auditArch = 0
+
+var archDeniedSyscalls []uintptr
@@ -0,0 +1,49 @@
+// Package http provides HTTP handlers for languages.
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	submissionApp "leetcode-api/internal/application/submission"
+)
+
+// LanguageHandler handles language-related HTTP requests
+type LanguageHandler struct {
+	service *submissionApp.Service
+}
+
+// NewLanguageHandler creates a new LanguageHandler
+func NewLanguageHandler(service *submissionApp.Service) *LanguageHandler {
+	return &LanguageHandler{service: service}
+}
+
+// LanguageResponse is the API response for a language
+type LanguageResponse struct {
+	ID             string  `json:"id"`
+	Name           string  `json:"name"`
+	Extension      string  `json:"extension"`
+	Version        string  `json:"version,omitempty"`
+	Available      bool    `json:"available"`
+	TimeMultiplier float64 `json:"timeMultiplier,omitempty"`
+}
+
+// List handles GET /api/languages
+func (h *LanguageHandler) List(c *gin.Context) {
+	languages := h.service.Languages()
+
+	response := make([]LanguageResponse, len(languages))
+	for i, l := range languages {
+		response[i] = LanguageResponse{
+			ID:             l.ID,
+			Name:           l.Name,
+			Extension:      l.Extension,
+			Version:        l.Version,
+			Available:      l.Available,
+			TimeMultiplier: l.TimeMultiplier,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"languages": response})
+}
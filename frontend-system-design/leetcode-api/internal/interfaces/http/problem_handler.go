@@ -34,6 +34,13 @@ type ProblemResponse struct {
 	Examples       string             `json:"examples,omitempty"`
 	Constraints    string             `json:"constraints,omitempty"`
 	StarterCode    string             `json:"starterCode,omitempty"`
+	MemoryLimit    int                `json:"memoryLimit,omitempty"` // in MB
+	CustomInput    bool               `json:"customInput,omitempty"` // whether /api/run accepts custom inputs
+	Params         []ParamResponse    `json:"params,omitempty"`      // arguments each custom input lists, in order
+	Output         string             `json:"output,omitempty"`      // parameter judged after the call instead of the return value
+	Operations     []MethodResponse   `json:"operations,omitempty"`  // calls custom inputs of design problems make, the constructor first
+	QueryLimit     int                `json:"queryLimit,omitempty"`  // queries per test of interactive problems, whose judge answers over stdin
+	IOMode         bool               `json:"ioMode,omitempty"`      // solutions are full programs reading the test input from stdin
 	Topics         []TopicResponse    `json:"topics,omitempty"`
 	TestCases      []TestCaseResponse `json:"testCases,omitempty"`
 }
@@ -46,11 +53,25 @@ type TopicResponse struct {
 	Count int    `json:"count,omitempty"`
 }
 
+// ParamResponse describes a parameter of the problem's entry point
+type ParamResponse struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// MethodResponse describes the constructor or a method of a design problem's class
+type MethodResponse struct {
+	Name       string          `json:"name"`
+	Params     []ParamResponse `json:"params"`
+	ReturnType string          `json:"returnType,omitempty"`
+}
+
 // TestCaseResponse is the API response for a test case
 type TestCaseResponse struct {
-	ID       uint   `json:"id"`
-	Input    string `json:"input"`
-	Expected string `json:"expected"`
+	ID       uint              `json:"id"`
+	Input    string            `json:"input"`
+	Expected string            `json:"expected"`
+	Files    map[string]string `json:"files,omitempty"`
 }
 
 // List handles GET /api/problems
@@ -158,6 +179,29 @@ func toProblemResponse(p domain.Problem, includeDetails bool) ProblemResponse {
 		resp.Examples = p.Examples
 		resp.Constraints = p.Constraints
 		resp.StarterCode = p.StarterCode
+		resp.MemoryLimit = p.MemoryLimitKB() / 1024
+		resp.CustomInput = p.AcceptsCustomInput()
+		resp.IOMode = p.IOMode
+		if p.Signature != nil {
+			resp.Params = toParamResponses(p.Signature.Params)
+			resp.Output = p.Signature.OutputParam
+		}
+		if p.Interactive != nil {
+			resp.QueryLimit = p.Interactive.QueryLimit
+		}
+		if p.Design != nil {
+			resp.Operations = append(resp.Operations, MethodResponse{
+				Name:   p.Design.ClassName,
+				Params: toParamResponses(p.Design.Constructor),
+			})
+			for _, m := range p.Design.Methods {
+				resp.Operations = append(resp.Operations, MethodResponse{
+					Name:       m.FunctionName,
+					Params:     toParamResponses(m.Params),
+					ReturnType: string(m.ReturnType),
+				})
+			}
+		}
 
 		resp.TestCases = make([]TestCaseResponse, len(p.TestCases))
 		for i, tc := range p.TestCases {
@@ -165,9 +209,19 @@ func toProblemResponse(p domain.Problem, includeDetails bool) ProblemResponse {
 				ID:       tc.ID,
 				Input:    tc.Input,
 				Expected: tc.Expected,
+				Files:    tc.Files,
 			}
 		}
 	}
 
 	return resp
 }
+
+// toParamResponses converts parameters to their API responses
+func toParamResponses(params []domain.Param) []ParamResponse {
+	resp := make([]ParamResponse, len(params))
+	for i, p := range params {
+		resp[i] = ParamResponse{Name: p.Name, Type: string(p.Type)}
+	}
+	return resp
+}
@@ -13,6 +13,7 @@ import (
 type Router struct {
 	problemHandler    *ProblemHandler
 	submissionHandler *SubmissionHandler
+	languageHandler   *LanguageHandler
 }
 
 // NewRouter creates a new Router
@@ -23,6 +24,7 @@ func NewRouter(
 	return &Router{
 		problemHandler:    NewProblemHandler(problemService),
 		submissionHandler: NewSubmissionHandler(submissionService),
+		languageHandler:   NewLanguageHandler(submissionService),
 	}
 }
 
@@ -48,10 +50,14 @@ func (r *Router) Setup() *gin.Engine {
 		// Topics
 		api.GET("/topics", r.problemHandler.GetTopics)
 
+		// Languages
+		api.GET("/languages", r.languageHandler.List)
+
 		// Submissions
 		api.POST("/run", r.submissionHandler.Run)
 		api.POST("/submit", r.submissionHandler.Submit)
 		api.GET("/submissions/:id", r.submissionHandler.Get)
+		api.GET("/submissions/:id/events", r.submissionHandler.Events)
 	}
 
 	return engine
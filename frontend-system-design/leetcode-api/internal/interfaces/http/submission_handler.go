@@ -2,12 +2,15 @@
 package http
 
 import (
+	"errors"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
 	submissionApp "leetcode-api/internal/application/submission"
 	domain "leetcode-api/internal/domain/submission"
+	"leetcode-api/pkg/apperrors"
 )
 
 // SubmissionHandler handles submission-related HTTP requests
@@ -25,6 +28,9 @@ type RunRequest struct {
 	ProblemID uint   `json:"problemId" binding:"required"`
 	Language  string `json:"language" binding:"required"`
 	Code      string `json:"code" binding:"required"`
+	// CustomInputs replaces the example tests of a run with inputs in the
+	// problem's format, such as "[[2,7,11,15], 9]"; submissions ignore it
+	CustomInputs []string `json:"customInputs"`
 }
 
 // SubmissionResponse is the API response for a submission
@@ -33,6 +39,10 @@ type SubmissionResponse struct {
 	Status       string               `json:"status"`
 	Passed       int                  `json:"passed,omitempty"`
 	Total        int                  `json:"total,omitempty"`
+	Runtime      int                  `json:"runtime"`
+	Memory       int                  `json:"memory"`
+	Stdout       string               `json:"stdout,omitempty"` // output of the test the status refers to
+	Error        string               `json:"error,omitempty"`  // why the judge failed
 	Results      []TestResultResponse `json:"results"`
 }
 
@@ -43,6 +53,23 @@ type TestResultResponse struct {
 	Actual   string `json:"actual"`
 	Passed   bool   `json:"passed"`
 	Runtime  int    `json:"runtime"`
+	Memory   int    `json:"memory"`
+	Status   string `json:"status,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Signal   string `json:"signal,omitempty"`
+	ExitCode int    `json:"exitCode,omitempty"`
+	Stdout   string `json:"stdout,omitempty"`
+	Stderr   string `json:"stderr,omitempty"`
+}
+
+// TestEventResponse is the payload of the events streamed for finished tests
+type TestEventResponse struct {
+	Index   int    `json:"index"`
+	Total   int    `json:"total"`
+	Passed  bool   `json:"passed"`
+	Status  string `json:"status,omitempty"`
+	Runtime int    `json:"runtime"`
+	Memory  int    `json:"memory"`
 }
 
 // Run handles POST /api/run
@@ -58,12 +85,14 @@ func (h *SubmissionHandler) Run(c *gin.Context) {
 		req.ProblemID,
 		req.Language,
 		req.Code,
+		req.CustomInputs,
 	)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": errorMessage(err)})
 		return
 	}
 
+	// For custom inputs, each result's expected output is the reference solution's
 	c.JSON(http.StatusOK, toSubmissionResponse(submission))
 }
 
@@ -82,15 +111,12 @@ func (h *SubmissionHandler) Submit(c *gin.Context) {
 		req.Code,
 	)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": errorMessage(err)})
 		return
 	}
 
-	resp := toSubmissionResponse(submission)
-	resp.Passed = submission.PassedCount()
-	resp.Total = submission.TotalCount()
-
-	c.JSON(http.StatusOK, resp)
+	// Judging happens in the background; clients poll GET /api/submissions/:id
+	c.JSON(http.StatusAccepted, toSubmissionResponse(submission))
 }
 
 // Get handles GET /api/submissions/:id
@@ -103,7 +129,76 @@ func (h *SubmissionHandler) Get(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, toSubmissionResponse(submission))
+	c.JSON(http.StatusOK, toStatusResponse(submission))
+}
+
+// Events handles GET /api/submissions/:id/events. It streams a "test" event
+// for every test as it finishes and a final "verdict" event carrying the
+// same body as GET /api/submissions/:id.
+func (h *SubmissionHandler) Events(c *gin.Context) {
+	events, err := h.service.Watch(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Submission not found"})
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	// Proxies must pass events on as they come
+	c.Header("X-Accel-Buffering", "no")
+	c.Stream(func(w io.Writer) bool {
+		event, ok := <-events
+		if !ok {
+			return false
+		}
+		if event.Test != nil {
+			c.SSEvent("test", TestEventResponse{
+				Index:   event.Test.Index,
+				Total:   event.Test.Total,
+				Passed:  event.Test.Result.Passed,
+				Status:  string(event.Test.Result.Status),
+				Runtime: event.Test.Result.Runtime,
+				Memory:  event.Test.Result.Memory,
+			})
+			return true
+		}
+		c.SSEvent("verdict", toStatusResponse(event.Verdict))
+		return false
+	})
+}
+
+// errorStatus maps an application error to its HTTP status
+func errorStatus(err error) int {
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) {
+		return http.StatusInternalServerError
+	}
+	switch appErr.Code {
+	case apperrors.ErrCodeValidation:
+		return http.StatusBadRequest
+	case apperrors.ErrCodeNotFound:
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// errorMessage returns the message of an error for API clients
+func errorMessage(err error) string {
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) && appErr.Code != apperrors.ErrCodeInternal {
+		return appErr.Message
+	}
+	return err.Error()
+}
+
+// toStatusResponse converts a polled submission, adding the test counts once judging is over
+func toStatusResponse(s *domain.Submission) SubmissionResponse {
+	resp := toSubmissionResponse(s)
+	if s.Status.Finished() {
+		resp.Passed = s.PassedCount()
+		resp.Total = s.TotalCount()
+	}
+	return resp
 }
 
 // toSubmissionResponse converts domain to API response
@@ -116,12 +211,23 @@ func toSubmissionResponse(s *domain.Submission) SubmissionResponse {
 			Actual:   r.Actual,
 			Passed:   r.Passed,
 			Runtime:  r.Runtime,
+			Memory:   r.Memory,
+			Status:   string(r.Status),
+			Error:    r.Error,
+			Signal:   r.Signal,
+			ExitCode: r.ExitCode,
+			Stdout:   r.Stdout,
+			Stderr:   r.Stderr,
 		}
 	}
 
 	return SubmissionResponse{
 		SubmissionID: s.ID,
 		Status:       string(s.Status),
+		Runtime:      s.Runtime,
+		Memory:       s.Memory,
+		Stdout:       s.Output,
+		Error:        s.Error,
 		Results:      results,
 	}
 }